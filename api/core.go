@@ -11,6 +11,19 @@ type Channel interface {
 	Chaincode(ctx context.Context, name string) (Chaincode, error)
 	// Join channel
 	Join(ctx context.Context) error
+	// JoinAll joins all peers of current MSP to channel with genesis block from orderer
+	JoinAll(ctx context.Context) ([]PeerJoinResult, error)
+	// JoinBySnapshot joins all peers of current MSP to channel from snapshot on peer file system (HLF Peer V2.3+)
+	JoinBySnapshot(ctx context.Context, snapshotDir string) ([]PeerJoinResult, error)
+}
+
+// PeerJoinResult - result of joining peer to channel
+type PeerJoinResult struct {
+	// Peer uri
+	Peer string
+	// AlreadyJoined is true if peer was joined to channel before, join is skipped
+	AlreadyJoined bool
+	Err           error
 }
 
 type Core interface {
@@ -22,6 +22,9 @@ var (
 	SeekToMax = proto.NewSeekSpecified(math.MaxUint64)
 )
 
+// AnyChaincode used in SubscribeCCs chaincode list for subscribing on events of all chaincodes
+const AnyChaincode = `*`
+
 type DeliverClient interface {
 	// SubscribeCC allows subscribing on chaincode events using name of channel, chaincode and block offset
 	SubscribeCC(ctx context.Context, channelName string, ccName string, seekOpt ...EventCCSeekOption) (EventCCSubscription, error)
@@ -31,6 +34,14 @@ type DeliverClient interface {
 	SubscribeBlock(ctx context.Context, channelName string, seekOpt ...EventCCSeekOption) (BlockSubscription, error)
 }
 
+// MultiCCDeliverClient allows subscribing on events of several chaincodes, implemented by client/deliver.Deliver
+type MultiCCDeliverClient interface {
+	DeliverClient
+	// SubscribeCCs allows subscribing on events of several chaincodes using one block stream.
+	// Events are delivered in commit order. Empty list or AnyChaincode means all chaincodes of channel
+	SubscribeCCs(ctx context.Context, channelName string, ccNames []string, seekOpt ...EventCCSeekOption) (EventCCsSubscription, error)
+}
+
 type EventCCSeekOption func() (*orderer.SeekPosition, *orderer.SeekPosition)
 
 // SeekNewest sets offset to new channel blocks
@@ -79,6 +90,34 @@ type EventCCSubscription interface {
 	}
 	// Errors returns errors associated with this subscription
 	Errors() chan error
+	// RangeComplete is closed when bounded seek range reaches its stop block
+	RangeComplete() <-chan struct{}
+	// Close cancels current subscription
+	Close() error
+}
+
+// EventPayload is optionally implemented by events of EventCCSubscription and EventCCsSubscription,
+// i.e. by client/deliver/subs.ChaincodeEventWithBlock. Check it with type assertion on received event
+type EventPayload interface {
+	// Payload returns event payload decoded with payload registry, nil if event name is not registered
+	Payload() interface{}
+	// PayloadErr returns error occurred while decoding event payload
+	PayloadErr() error
+}
+
+// EventCCsSubscription describes subscription on events of several chaincodes
+type EventCCsSubscription interface {
+	// Events returns channel on chaincode events with position in ledger, ordered by commit
+	Events() chan interface {
+		Event() *peer.ChaincodeEvent
+		Block() uint64
+		TxIndex() int
+		TxTimestamp() *timestamp.Timestamp
+	}
+	// Errors returns errors associated with this subscription
+	Errors() chan error
+	// RangeComplete is closed when bounded seek range reaches its stop block
+	RangeComplete() <-chan struct{}
 	// Close cancels current subscription
 	Close() error
 }
@@ -94,6 +133,8 @@ type BlockSubscription interface {
 	Blocks() <-chan *common.Block
 	// DEPRECATED: will migrate to just once Err() <- chan error
 	Errors() chan error
+	// RangeComplete is closed when bounded seek range reaches its stop block
+	RangeComplete() <-chan struct{}
 	Close() error
 }
 
@@ -113,6 +154,58 @@ func (e GRPCStreamError) Error() string {
 	return fmt.Sprintf("grpc stream error: %s", e.Err)
 }
 
+const (
+	ErrDeliverForbidden = Error(`deliver forbidden`)
+	ErrChannelNotFound  = Error(`channel not found`)
+	ErrBlockNotFound    = Error(`block not found`)
+	// ErrDeliverNotFound - peer returns NOT_FOUND status both for unknown channel and for block
+	// beyond ledger height (FAIL_IF_NOT_READY seek), errors.Is matches it with ErrChannelNotFound and ErrBlockNotFound
+	ErrDeliverNotFound           = Error(`channel or block not found`)
+	ErrDeliverBadRequest         = Error(`deliver bad request`)
+	ErrDeliverServiceUnavailable = Error(`deliver service unavailable`)
+	ErrDeliverUnexpectedStatus   = Error(`deliver unexpected status`)
+)
+
+// DeliverStatusError contains non success status received from deliver stream
+type DeliverStatusError struct {
+	Status common.Status
+	Err    error
+}
+
+func (e DeliverStatusError) Error() string {
+	return fmt.Sprintf("deliver status %s: %s", e.Status, e.Err)
+}
+
+func (e DeliverStatusError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports NOT_FOUND status as both ErrChannelNotFound and ErrBlockNotFound, peer doesn't distinguish them
+func (e DeliverStatusError) Is(target error) bool {
+	return e.Err == ErrDeliverNotFound && (target == ErrChannelNotFound || target == ErrBlockNotFound)
+}
+
+// NewDeliverStatusError converts deliver stream status to error, returns nil for SUCCESS status
+func NewDeliverStatusError(status common.Status) error {
+	var err error
+	switch status {
+	case common.Status_SUCCESS:
+		return nil
+	case common.Status_FORBIDDEN:
+		err = ErrDeliverForbidden
+	case common.Status_NOT_FOUND:
+		err = ErrDeliverNotFound
+	case common.Status_BAD_REQUEST:
+		err = ErrDeliverBadRequest
+	case common.Status_SERVICE_UNAVAILABLE:
+		err = ErrDeliverServiceUnavailable
+	default:
+		err = ErrDeliverUnexpectedStatus
+	}
+
+	return DeliverStatusError{Status: status, Err: err}
+}
+
 type EnvelopeParsingError struct {
 	Err error
 }
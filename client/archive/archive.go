@@ -0,0 +1,156 @@
+// Package archive exports channel blocks to portable files and serves them back as api.BlocksDeliverer.
+//
+// Archive layout, compatible with client/testing.BlocksDelivererMock:
+//
+//	<root>/<channel>/<n>.pb            raw protobuf blocks
+//	<root>/<channel>/blocks-<n>.jsonl  parsed blocks (proto.ParseBlock), one per line
+//	<root>/<channel>/manifest.jsonl    block hashes, one per line
+//	<root>/<channel>/progress.json     next block to export
+package archive
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+const (
+	ManifestFile = `manifest.jsonl`
+	ProgressFile = `progress.json`
+
+	blockFileExt     = `.pb`
+	jsonLinesPrefix  = `blocks`
+	jsonLinesFileExt = `.jsonl`
+)
+
+var (
+	ErrChannelNotArchived = errors.New(`channel is not archived`)
+	ErrBlockNotArchived   = errors.New(`block is not archived`)
+	ErrBlockHashMismatch  = errors.New(`block header hash does not match manifest`)
+)
+
+type (
+	// Format of exported blocks
+	Format int
+
+	// ManifestEntry - block hashes, written to manifest after block files
+	ManifestEntry struct {
+		Number       uint64 `json:"number"`
+		Hash         string `json:"hash"`
+		PreviousHash string `json:"previous_hash"`
+		DataHash     string `json:"data_hash"`
+	}
+
+	// Progress - state of export, used for resume. Files are truncated to offsets on resume,
+	// so lines of block written before crash but not recorded in progress are not duplicated
+	Progress struct {
+		NextBlock uint64 `json:"next_block"`
+		// JSONLinesFile - name of JSON lines file with last exported block, JSONLinesOffset - its size after block line
+		JSONLinesFile   string `json:"json_lines_file,omitempty"`
+		JSONLinesOffset int64  `json:"json_lines_offset,omitempty"`
+		// ManifestOffset - manifest size after entry of last exported block
+		ManifestOffset int64 `json:"manifest_offset,omitempty"`
+	}
+)
+
+const (
+	// FormatProto - raw protobuf block per file
+	FormatProto Format = 1 << iota
+	// FormatJSON - parsed blocks as JSON lines
+	FormatJSON
+	// FormatBoth - raw protobuf and JSON lines
+	FormatBoth = FormatProto | FormatJSON
+)
+
+func NewManifestEntry(block *common.Block) ManifestEntry {
+	return ManifestEntry{
+		Number:       block.Header.Number,
+		Hash:         hex.EncodeToString(protoutil.BlockHeaderHash(block.Header)),
+		PreviousHash: hex.EncodeToString(block.Header.PreviousHash),
+		DataHash:     hex.EncodeToString(block.Header.DataHash),
+	}
+}
+
+// ReadManifest returns manifest entries by block number, later entry for the same block wins
+func ReadManifest(root, channel string) (map[uint64]ManifestEntry, error) {
+	f, err := os.Open(filepath.Join(root, channel, ManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf(`open manifest: %w`, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	entries := make(map[uint64]ManifestEntry)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry ManifestEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf(`unmarshal manifest entry: %w`, err)
+		}
+		entries[entry.Number] = entry
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf(`read manifest: %w`, err)
+	}
+
+	return entries, nil
+}
+
+// ReadProgress returns export progress, zero progress if channel was not exported yet
+func ReadProgress(root, channel string) (Progress, error) {
+	var progress Progress
+
+	data, err := os.ReadFile(filepath.Join(root, channel, ProgressFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return progress, nil
+		}
+		return progress, fmt.Errorf(`read progress: %w`, err)
+	}
+
+	if err = json.Unmarshal(data, &progress); err != nil {
+		return progress, fmt.Errorf(`unmarshal progress: %w`, err)
+	}
+
+	return progress, nil
+}
+
+// writeProgress writes progress to temp file and renames it, so progress file is never partially written
+func writeProgress(root, channel string, progress Progress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(root, channel, ProgressFile)
+	if err = os.WriteFile(path+`.tmp`, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(path+`.tmp`, path)
+}
+
+func blockPath(root, channel string, number uint64) string {
+	return filepath.Join(root, channel, strconv.FormatUint(number, 10)+blockFileExt)
+}
+
+// jsonLinesPath returns JSON lines file for block, with rotation files are aligned by rotation size
+func jsonLinesPath(root, channel string, number, rotateEvery uint64) string {
+	if rotateEvery == 0 {
+		return filepath.Join(root, channel, jsonLinesPrefix+jsonLinesFileExt)
+	}
+
+	return filepath.Join(root, channel,
+		fmt.Sprintf(`%s-%d%s`, jsonLinesPrefix, number-number%rotateEvery, jsonLinesFileExt))
+}
@@ -0,0 +1,242 @@
+package archive_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/client/archive"
+	sdktesting "github.com/vitiko/hlf-sdk-go/client/testing"
+)
+
+// blocksSource delivers chained blocks from range start to the end of slice
+type blocksSource struct {
+	blocks []*common.Block
+}
+
+func (s *blocksSource) Blocks(_ context.Context, _ string, _ msp.SigningIdentity, blockRange ...int64) (
+	<-chan *common.Block, func() error, error) {
+	from := 0
+	if len(blockRange) > 0 {
+		from = int(blockRange[0])
+	}
+
+	ch := make(chan *common.Block, len(s.blocks))
+	for _, b := range s.blocks[from:] {
+		ch <- b
+	}
+	close(ch)
+
+	return ch, func() error { return nil }, nil
+}
+
+// failingSource fails test if blocks are requested
+type failingSource struct {
+	t *testing.T
+}
+
+func (s *failingSource) Blocks(_ context.Context, _ string, _ msp.SigningIdentity, blockRange ...int64) (
+	<-chan *common.Block, func() error, error) {
+	s.t.Fatalf("unexpected blocks request: %v", blockRange)
+	return nil, nil, nil
+}
+
+func countLines(t *testing.T, path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %s", path, err)
+	}
+	return bytes.Count(data, []byte("\n"))
+}
+
+// appendLine simulates crash after line of block was written, but before progress
+func appendLine(t *testing.T, path string) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("open %s: %s", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if _, err = f.Write([]byte("{}\n")); err != nil {
+		t.Fatalf("write %s: %s", path, err)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	blocks := sdktesting.NewChainedBlocks(8)
+
+	exporter := archive.NewExporter(&blocksSource{blocks: blocks[:5]}, root,
+		archive.WithFormat(archive.FormatBoth), archive.WithRotation(2))
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("export: %s", err)
+	}
+
+	for _, name := range []string{`0.pb`, `4.pb`, `blocks-0.jsonl`, `blocks-2.jsonl`, `blocks-4.jsonl`, archive.ManifestFile} {
+		if _, err := os.Stat(filepath.Join(root, `channel`, name)); err != nil {
+			t.Fatalf("archive file %s: %s", name, err)
+		}
+	}
+
+	// resume from progress
+	exporter = archive.NewExporter(&blocksSource{blocks: blocks}, root, archive.WithFormat(archive.FormatBoth))
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("resume export: %s", err)
+	}
+
+	progress, err := archive.ReadProgress(root, `channel`)
+	if err != nil {
+		t.Fatalf("read progress: %s", err)
+	}
+	if progress.NextBlock != 8 {
+		t.Fatalf("progress: expected= 8, got= %d", progress.NextBlock)
+	}
+
+	importer := archive.NewImporter(root, archive.WithManifestCheck())
+	if err = importer.Verify(`channel`); err != nil {
+		t.Fatalf("verify: %s", err)
+	}
+
+	imported, closer, err := importer.Blocks(ctx, `channel`, nil, 2, -2)
+	if err != nil {
+		t.Fatalf("import: %s", err)
+	}
+	defer func() { _ = closer() }()
+
+	expected := uint64(2)
+	for b := range imported {
+		if b.Header.Number != expected {
+			t.Fatalf("block: expected= %d, got= %d", expected, b.Header.Number)
+		}
+		expected++
+	}
+	if expected != 7 {
+		t.Fatalf("last block: expected= 6, got= %d", expected-1)
+	}
+
+	// replace block with block from other chain
+	other := protoutil.MarshalOrPanic(protoutil.NewBlock(3, []byte(`other`)))
+	if err = os.WriteFile(filepath.Join(root, `channel`, `3.pb`), other, 0644); err != nil {
+		t.Fatalf("write block: %s", err)
+	}
+	if err = importer.Verify(`channel`); !errors.Is(err, archive.ErrBlockHashMismatch) {
+		t.Fatalf("verify: expected= %s, got= %v", archive.ErrBlockHashMismatch, err)
+	}
+}
+
+func TestImport_Truncated(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	exporter := archive.NewExporter(&blocksSource{blocks: sdktesting.NewChainedBlocks(5)}, root)
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("export: %s", err)
+	}
+
+	if err := os.Remove(filepath.Join(root, `channel`, `2.pb`)); err != nil {
+		t.Fatalf("remove block: %s", err)
+	}
+
+	imported, closer, err := archive.NewImporter(root).Blocks(ctx, `channel`, nil)
+	if err != nil {
+		t.Fatalf("import: %s", err)
+	}
+
+	var count int
+	for range imported {
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("imported blocks: expected= 2, got= %d", count)
+	}
+
+	if err = closer(); !errors.Is(err, archive.ErrBlockNotArchived) {
+		t.Fatalf("closer: expected= %s, got= %v", archive.ErrBlockNotArchived, err)
+	}
+
+	// export from truncated archive reports import error
+	exporter = archive.NewExporter(archive.NewImporter(root), t.TempDir())
+	if err = exporter.Export(ctx, `channel`); !errors.Is(err, archive.ErrBlockNotArchived) {
+		t.Fatalf("export from archive: expected= %s, got= %v", archive.ErrBlockNotArchived, err)
+	}
+}
+
+func TestExport_BlockRangeNotModified(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	blocks := sdktesting.NewChainedBlocks(6)
+
+	exporter := archive.NewExporter(&blocksSource{blocks: blocks[:3]}, root)
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("export: %s", err)
+	}
+
+	blockRange := []int64{0, 5}
+	exporter = archive.NewExporter(&blocksSource{blocks: blocks}, root)
+	if err := exporter.Export(ctx, `channel`, blockRange...); err != nil {
+		t.Fatalf("resume export: %s", err)
+	}
+
+	if blockRange[0] != 0 || blockRange[1] != 5 {
+		t.Fatalf("block range: expected= [0 5], got= %v", blockRange)
+	}
+}
+
+func TestExport_ResumeAfterCrash(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	blocks := sdktesting.NewChainedBlocks(6)
+	dir := filepath.Join(root, `channel`)
+
+	exporter := archive.NewExporter(&blocksSource{blocks: blocks[:3]}, root,
+		archive.WithFormat(archive.FormatJSON), archive.WithRotation(3))
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("export: %s", err)
+	}
+
+	// lines of block 3 are written to manifest and new rotated file, progress is not
+	appendLine(t, filepath.Join(dir, archive.ManifestFile))
+	appendLine(t, filepath.Join(dir, `blocks-3.jsonl`))
+	// line of block 2 written again
+	appendLine(t, filepath.Join(dir, `blocks-0.jsonl`))
+
+	exporter = archive.NewExporter(&blocksSource{blocks: blocks}, root,
+		archive.WithFormat(archive.FormatJSON), archive.WithRotation(3))
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("resume export: %s", err)
+	}
+
+	for name, expected := range map[string]int{
+		archive.ManifestFile: 6,
+		`blocks-0.jsonl`:     3,
+		`blocks-3.jsonl`:     3,
+	} {
+		if lines := countLines(t, filepath.Join(dir, name)); lines != expected {
+			t.Fatalf("%s lines: expected= %d, got= %d", name, expected, lines)
+		}
+	}
+}
+
+func TestExport_RangeAlreadyExported(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+
+	exporter := archive.NewExporter(&blocksSource{blocks: sdktesting.NewChainedBlocks(5)}, root)
+	if err := exporter.Export(ctx, `channel`); err != nil {
+		t.Fatalf("export: %s", err)
+	}
+
+	// progress is beyond range end
+	exporter = archive.NewExporter(&failingSource{t: t}, root)
+	if err := exporter.Export(ctx, `channel`, 0, 3); err != nil {
+		t.Fatalf("export exported range: %s", err)
+	}
+}
@@ -0,0 +1,319 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/msp"
+	"go.uber.org/zap"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+type (
+	// Exporter streams channel blocks from deliverer and writes them to archive
+	Exporter struct {
+		source      api.BlocksDeliverer
+		root        string
+		format      Format
+		rotateEvery uint64
+		identity    msp.SigningIdentity
+		logger      *zap.Logger
+	}
+
+	ExporterOpt func(*Exporter)
+)
+
+// WithFormat sets format of exported blocks, FormatProto by default
+func WithFormat(format Format) ExporterOpt {
+	return func(e *Exporter) {
+		e.format = format
+	}
+}
+
+// WithRotation starts new JSON lines file every blocksPerFile blocks
+func WithRotation(blocksPerFile uint64) ExporterOpt {
+	return func(e *Exporter) {
+		e.rotateEvery = blocksPerFile
+	}
+}
+
+// WithIdentity sets identity used for blocks delivery, if nil default identity of deliverer is used
+func WithIdentity(identity msp.SigningIdentity) ExporterOpt {
+	return func(e *Exporter) {
+		e.identity = identity
+	}
+}
+
+func WithLogger(logger *zap.Logger) ExporterOpt {
+	return func(e *Exporter) {
+		e.logger = logger
+	}
+}
+
+func NewExporter(source api.BlocksDeliverer, root string, opts ...ExporterOpt) *Exporter {
+	e := &Exporter{
+		source: source,
+		root:   root,
+		format: FormatProto,
+		logger: zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Export writes channel blocks to archive until block range end or context cancellation.
+// Block range is the same as in api.BlocksDeliverer, range start is ignored if channel was exported before:
+// export resumes from progress, nothing is exported if progress is beyond range end.
+// JSON lines rotation must not be changed between resumed exports
+func (e *Exporter) Export(ctx context.Context, channel string, blockRange ...int64) error {
+	if err := os.MkdirAll(filepath.Join(e.root, channel), 0755); err != nil {
+		return fmt.Errorf(`create channel dir: %w`, err)
+	}
+
+	progress, err := ReadProgress(e.root, channel)
+	if err != nil {
+		return err
+	}
+
+	if progress.NextBlock > 0 {
+		if len(blockRange) > 1 && blockRange[1] >= 0 && uint64(blockRange[1]) < progress.NextBlock {
+			e.logger.Debug(`channel blocks already exported`,
+				zap.String(`channel`, channel),
+				zap.Uint64(`next block`, progress.NextBlock),
+				zap.Int64(`range end`, blockRange[1]))
+			return nil
+		}
+
+		// caller's block range is not modified
+		resumed := []int64{int64(progress.NextBlock)}
+		if len(blockRange) > 1 {
+			resumed = append(resumed, blockRange[1:]...)
+		}
+		blockRange = resumed
+
+		if err = e.truncate(channel, progress); err != nil {
+			return err
+		}
+	}
+
+	e.logger.Debug(`export channel blocks`,
+		zap.String(`channel`, channel),
+		zap.Reflect(`range`, blockRange))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blocks, closer, err := e.source.Blocks(ctx, channel, e.identity, blockRange...)
+	if err != nil {
+		return fmt.Errorf(`subscribe blocks: %w`, err)
+	}
+	defer func() { _ = closer() }()
+
+	w := &channelWriter{exporter: e, channel: channel}
+	defer w.close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case block, ok := <-blocks:
+			// source closer reports why block channel was closed, i.e. truncated archive import
+			if !ok {
+				if err = closer(); err != nil {
+					return fmt.Errorf(`blocks source: %w`, err)
+				}
+				return nil
+			}
+
+			// block is already in archive, i.e. delivered before progress was saved
+			if block.Header.Number < progress.NextBlock {
+				continue
+			}
+
+			if err = w.write(block); err != nil {
+				return fmt.Errorf(`write block=%d: %w`, block.Header.Number, err)
+			}
+
+			progress.NextBlock = block.Header.Number + 1
+			progress.JSONLinesFile = filepath.Base(w.jsonLinesPath)
+			progress.JSONLinesOffset = w.jsonLinesOffset
+			progress.ManifestOffset = w.manifestOffset
+			if err = writeProgress(e.root, channel, progress); err != nil {
+				return fmt.Errorf(`write progress: %w`, err)
+			}
+		}
+	}
+}
+
+// truncate removes lines written after progress was saved, i.e. on crash between block write and progress write
+func (e *Exporter) truncate(channel string, progress Progress) error {
+	// progress without offsets
+	if progress.ManifestOffset == 0 {
+		return nil
+	}
+
+	if err := truncateFile(filepath.Join(e.root, channel, ManifestFile), progress.ManifestOffset); err != nil {
+		return fmt.Errorf(`truncate manifest: %w`, err)
+	}
+
+	if e.format&FormatJSON == 0 {
+		return nil
+	}
+
+	if progress.JSONLinesFile != `` {
+		if err := truncateFile(filepath.Join(e.root, channel, progress.JSONLinesFile), progress.JSONLinesOffset); err != nil {
+			return fmt.Errorf(`truncate json lines file: %w`, err)
+		}
+	}
+
+	// next block starts new rotated file, file can contain only lines written after progress
+	next := jsonLinesPath(e.root, channel, progress.NextBlock, e.rotateEvery)
+	if filepath.Base(next) != progress.JSONLinesFile {
+		if err := truncateFile(next, 0); err != nil {
+			return fmt.Errorf(`truncate json lines file: %w`, err)
+		}
+	}
+
+	return nil
+}
+
+// truncateFile truncates existing file to size, missing file is ignored
+func truncateFile(path string, size int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	if info.Size() <= size {
+		return nil
+	}
+
+	return os.Truncate(path, size)
+}
+
+// channelWriter keeps opened JSON lines and manifest files of channel
+type channelWriter struct {
+	exporter *Exporter
+	channel  string
+
+	jsonLines       *os.File
+	jsonLinesPath   string
+	jsonLinesOffset int64
+	manifest        *os.File
+	manifestOffset  int64
+}
+
+func (w *channelWriter) write(block *common.Block) error {
+	e := w.exporter
+
+	if e.format&FormatProto != 0 {
+		data, err := proto.Marshal(block)
+		if err != nil {
+			return fmt.Errorf(`marshal block: %w`, err)
+		}
+		if err = os.WriteFile(blockPath(e.root, w.channel, block.Header.Number), data, 0644); err != nil {
+			return fmt.Errorf(`write block file: %w`, err)
+		}
+	}
+
+	if e.format&FormatJSON != 0 {
+		if err := w.writeJSONLine(block); err != nil {
+			return err
+		}
+	}
+
+	if w.manifest == nil {
+		f, err := os.OpenFile(filepath.Join(e.root, w.channel, ManifestFile), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf(`open manifest: %w`, err)
+		}
+		w.manifest = f
+	}
+
+	offset, err := appendJSONLine(w.manifest, NewManifestEntry(block))
+	if err != nil {
+		return err
+	}
+	w.manifestOffset = offset
+
+	return nil
+}
+
+func (w *channelWriter) writeJSONLine(block *common.Block) error {
+	e := w.exporter
+
+	parsed, err := hlfproto.ParseBlock(block)
+	if err != nil {
+		return fmt.Errorf(`parse block: %w`, err)
+	}
+
+	path := jsonLinesPath(e.root, w.channel, block.Header.Number, e.rotateEvery)
+	if path != w.jsonLinesPath {
+		if w.jsonLines != nil {
+			if err = w.jsonLines.Close(); err != nil {
+				return fmt.Errorf(`close json lines file: %w`, err)
+			}
+		}
+
+		if w.jsonLines, err = os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644); err != nil {
+			return fmt.Errorf(`open json lines file: %w`, err)
+		}
+		w.jsonLinesPath = path
+	}
+
+	offset, err := appendJSONLine(w.jsonLines, parsed)
+	if err != nil {
+		return err
+	}
+	w.jsonLinesOffset = offset
+
+	return nil
+}
+
+func (w *channelWriter) close() {
+	if w.jsonLines != nil {
+		_ = w.jsonLines.Close()
+	}
+	if w.manifest != nil {
+		_ = w.manifest.Close()
+	}
+}
+
+// appendJSONLine writes line to file opened for append and returns file size after line
+func appendJSONLine(f *os.File, v interface{}) (int64, error) {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return 0, fmt.Errorf(`marshal json line: %w`, err)
+	}
+
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		return 0, fmt.Errorf(`write json line: %w`, err)
+	}
+
+	if err = f.Sync(); err != nil {
+		return 0, fmt.Errorf(`sync json lines file: %w`, err)
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf(`stat json lines file: %w`, err)
+	}
+
+	return info.Size(), nil
+}
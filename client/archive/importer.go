@@ -0,0 +1,235 @@
+package archive
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"go.uber.org/zap"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+)
+
+var _ api.BlocksDeliverer = (*Importer)(nil)
+
+type (
+	// Importer serves archived blocks as api.BlocksDeliverer, archive must contain protobuf blocks
+	Importer struct {
+		root          string
+		manifestCheck bool
+		logger        *zap.Logger
+	}
+
+	ImporterOpt func(*Importer)
+)
+
+// WithManifestCheck enables check of block header hash against manifest
+func WithManifestCheck() ImporterOpt {
+	return func(i *Importer) {
+		i.manifestCheck = true
+	}
+}
+
+func WithImporterLogger(logger *zap.Logger) ImporterOpt {
+	return func(i *Importer) {
+		i.logger = logger
+	}
+}
+
+func NewImporter(root string, opts ...ImporterOpt) *Importer {
+	i := &Importer{
+		root:   root,
+		logger: zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// Blocks returns archived blocks of channel, block channel is closed after range end.
+// Block range is the same as in api.BlocksDeliverer: negative values are relative to archive height.
+// Reading stops on first missing block or manifest mismatch and block channel is closed,
+// closer waits for reading to stop and returns that error, so truncated import can be told from completed one
+func (i *Importer) Blocks(
+	ctx context.Context,
+	channel string,
+	_ msp.SigningIdentity,
+	blockRange ...int64,
+) (blockChan <-chan *common.Block, closer func() error, err error) {
+	first, last, err := i.Bounds(channel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	from, to := resolveRange(first, last, blockRange...)
+
+	var manifest map[uint64]ManifestEntry
+	if i.manifestCheck {
+		if manifest, err = ReadManifest(i.root, channel); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	blocks := make(chan *common.Block)
+	done := make(chan struct{})
+	var readErr error
+
+	go func() {
+		defer close(done)
+		defer close(blocks)
+
+		for num := from; num <= to; num++ {
+			block, err := i.readBlock(channel, num, manifest)
+			if err != nil {
+				i.logger.Error(`read archived block`,
+					zap.String(`channel`, channel), zap.Uint64(`block`, num), zap.Error(err))
+				readErr = err
+				return
+			}
+
+			select {
+			case blocks <- block:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return blocks, func() error {
+		cancel()
+		<-done
+		return readErr
+	}, nil
+}
+
+// Bounds returns numbers of first and last archived protobuf blocks
+func (i *Importer) Bounds(channel string) (first, last uint64, err error) {
+	entries, err := os.ReadDir(filepath.Join(i.root, channel))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, 0, fmt.Errorf(`channel=%s: %w`, channel, ErrChannelNotArchived)
+		}
+		return 0, 0, fmt.Errorf(`read channel dir: %w`, err)
+	}
+
+	found := false
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != blockFileExt {
+			continue
+		}
+
+		num, err := strconv.ParseUint(strings.TrimSuffix(entry.Name(), blockFileExt), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if !found || num < first {
+			first = num
+		}
+		if !found || num > last {
+			last = num
+		}
+		found = true
+	}
+
+	if !found {
+		return 0, 0, fmt.Errorf(`channel=%s has no protobuf blocks: %w`, channel, ErrChannelNotArchived)
+	}
+
+	return first, last, nil
+}
+
+// Verify checks that all archived protobuf blocks are present and match manifest
+func (i *Importer) Verify(channel string) error {
+	first, last, err := i.Bounds(channel)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := ReadManifest(i.root, channel)
+	if err != nil {
+		return err
+	}
+
+	for num := first; num <= last; num++ {
+		if _, err = i.readBlock(channel, num, manifest); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (i *Importer) readBlock(channel string, num uint64, manifest map[uint64]ManifestEntry) (*common.Block, error) {
+	data, err := os.ReadFile(blockPath(i.root, channel, num))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf(`block=%d: %w`, num, ErrBlockNotArchived)
+		}
+		return nil, fmt.Errorf(`read block=%d: %w`, num, err)
+	}
+
+	block := &common.Block{}
+	if err = proto.Unmarshal(data, block); err != nil {
+		return nil, fmt.Errorf(`unmarshal block=%d: %w`, num, err)
+	}
+
+	if manifest != nil {
+		entry, ok := manifest[num]
+		if !ok || entry.Hash != hex.EncodeToString(protoutil.BlockHeaderHash(block.Header)) {
+			return nil, fmt.Errorf(`block=%d: %w`, num, ErrBlockHashMismatch)
+		}
+	}
+
+	return block, nil
+}
+
+// resolveRange converts block range to absolute inclusive bounds within archive
+func resolveRange(first, last uint64, blockRange ...int64) (from, to uint64) {
+	from, to = first, last
+	height := int64(last) + 1
+
+	if len(blockRange) > 0 {
+		if blockRange[0] < 0 {
+			from = uint64(max64(height+blockRange[0], 0))
+		} else {
+			from = uint64(blockRange[0])
+		}
+	}
+
+	if len(blockRange) > 1 {
+		if blockRange[1] < 0 {
+			to = uint64(max64(height+blockRange[1], 0))
+		} else {
+			to = uint64(blockRange[1])
+		}
+	}
+
+	if from < first {
+		from = first
+	}
+	if to > last {
+		to = last
+	}
+
+	return from, to
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
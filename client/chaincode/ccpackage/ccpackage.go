@@ -0,0 +1,248 @@
+// Package ccpackage creates chaincode install packages for _lifecycle (Fabric 2.x) and computes package ID
+// the same way as peer does
+package ccpackage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/golang"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/java"
+	"github.com/hyperledger/fabric/core/chaincode/platforms/node"
+)
+
+// Chaincode types
+const (
+	TypeGolang = `golang`
+	TypeNode   = `node`
+	TypeJava   = `java`
+	// TypeExternal - chaincode launched by external builder
+	TypeExternal = `external`
+	// TypeCCaaS - chaincode as a service, supported by builtin peer builder since Fabric 2.4.1
+	TypeCCaaS = `ccaas`
+)
+
+const (
+	MetadataFile   = persistence.MetadataFile
+	CodeFile       = persistence.CodePackageFile
+	ConnectionFile = `connection.json`
+)
+
+var (
+	ErrUnknownChaincodeType = errors.New(`unknown chaincode type`)
+	ErrMetadataNotFound     = errors.New(`package metadata not found`)
+	ErrCodeNotFound         = errors.New(`package code not found`)
+)
+
+type (
+	// Metadata - content of metadata.json in package
+	Metadata struct {
+		Path  string `json:"path"`
+		Type  string `json:"type"`
+		Label string `json:"label"`
+	}
+
+	// Connection - content of connection.json for chaincode as a service, PEM fields contain PEM data
+	Connection struct {
+		Address            string `json:"address"`
+		DialTimeout        string `json:"dial_timeout,omitempty"`
+		TLSRequired        bool   `json:"tls_required"`
+		ClientAuthRequired bool   `json:"client_auth_required,omitempty"`
+		ClientKey          string `json:"client_key,omitempty"`
+		ClientCert         string `json:"client_cert,omitempty"`
+		RootCert           string `json:"root_cert,omitempty"`
+	}
+
+	tarFile struct {
+		name    string
+		content []byte
+	}
+
+	platform interface {
+		GetDeploymentPayload(path string) ([]byte, error)
+	}
+)
+
+// New creates package from chaincode source path: golang module or package path, node or java project directory.
+// Golang packaging requires go tool as peer CLI does
+func New(ccType, path, label string) ([]byte, error) {
+	var p platform
+	switch strings.ToLower(ccType) {
+	case TypeGolang:
+		p = &golang.Platform{}
+	case TypeNode:
+		p = &node.Platform{}
+	case TypeJava:
+		p = &java.Platform{}
+	default:
+		return nil, fmt.Errorf(`type=%s: %w`, ccType, ErrUnknownChaincodeType)
+	}
+
+	normalizedPath := path
+	if golangPlatform, ok := p.(*golang.Platform); ok {
+		var err error
+		if normalizedPath, err = golangPlatform.NormalizePath(path); err != nil {
+			return nil, fmt.Errorf(`normalize chaincode path: %w`, err)
+		}
+	}
+
+	code, err := p.GetDeploymentPayload(path)
+	if err != nil {
+		return nil, fmt.Errorf(`chaincode code: %w`, err)
+	}
+
+	return FromCode(Metadata{Path: normalizedPath, Type: strings.ToLower(ccType), Label: label}, code)
+}
+
+// NewService creates chaincode as a service package, code contains connection.json only.
+// ccType is TypeCCaaS or TypeExternal, depending on builder configured on peer
+func NewService(ccType, label string, connection Connection) ([]byte, error) {
+	connectionBytes, err := json.Marshal(connection)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal connection: %w`, err)
+	}
+
+	code, err := TarGz(map[string][]byte{ConnectionFile: connectionBytes})
+	if err != nil {
+		return nil, fmt.Errorf(`chaincode code: %w`, err)
+	}
+
+	return FromCode(Metadata{Type: ccType, Label: label}, code)
+}
+
+// FromCode creates package with metadata and code.tar.gz
+func FromCode(metadata Metadata, code []byte) ([]byte, error) {
+	if err := persistence.ValidateLabel(metadata.Label); err != nil {
+		return nil, err
+	}
+
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal metadata: %w`, err)
+	}
+
+	// metadata.json goes first, as in packages created by peer CLI
+	return tarGz(
+		tarFile{name: MetadataFile, content: metadataBytes},
+		tarFile{name: CodeFile, content: code})
+}
+
+// TarGz creates tar.gz archive with files in order of names
+func TarGz(files map[string][]byte) ([]byte, error) {
+	tarFiles := make([]tarFile, 0, len(files))
+	for name, content := range files {
+		tarFiles = append(tarFiles, tarFile{name: name, content: content})
+	}
+	sort.Slice(tarFiles, func(i, j int) bool {
+		return tarFiles[i].name < tarFiles[j].name
+	})
+
+	return tarGz(tarFiles...)
+}
+
+func tarGz(files ...tarFile) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+
+	for _, file := range files {
+		if err := writeFile(tw, file.name, file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf(`close tar: %w`, err)
+	}
+	if err := gw.Close(); err != nil {
+		return nil, fmt.Errorf(`close gzip: %w`, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ID returns package ID of package, label is read from package metadata
+func ID(pkg []byte) (string, error) {
+	metadata, err := ReadMetadata(pkg)
+	if err != nil {
+		return ``, err
+	}
+
+	return PackageID(metadata.Label, pkg), nil
+}
+
+// PackageID returns package ID as peer computes it on install: label:hex(sha256(package))
+func PackageID(label string, pkg []byte) string {
+	hash := sha256.Sum256(pkg)
+	return fmt.Sprintf(`%s:%x`, label, hash)
+}
+
+// ReadMetadata returns metadata of package
+func ReadMetadata(pkg []byte) (*Metadata, error) {
+	metadataBytes, err := readFile(pkg, MetadataFile, ErrMetadataNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &Metadata{}
+	if err = json.Unmarshal(metadataBytes, metadata); err != nil {
+		return nil, fmt.Errorf(`unmarshal metadata: %w`, err)
+	}
+
+	return metadata, nil
+}
+
+// ReadCode returns code.tar.gz of package
+func ReadCode(pkg []byte) ([]byte, error) {
+	return readFile(pkg, CodeFile, ErrCodeNotFound)
+}
+
+func readFile(archive []byte, name string, errNotFound error) ([]byte, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf(`read gzip: %w`, err)
+	}
+
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf(`read tar: %w`, err)
+		}
+
+		if header.Name == name {
+			return ioutil.ReadAll(tr)
+		}
+	}
+
+	return nil, errNotFound
+}
+
+func writeFile(tw *tar.Writer, name string, content []byte) error {
+	if err := tw.WriteHeader(&tar.Header{
+		Name: name,
+		Size: int64(len(content)),
+		Mode: 0100644,
+	}); err != nil {
+		return fmt.Errorf(`write tar header=%s: %w`, name, err)
+	}
+
+	if _, err := tw.Write(content); err != nil {
+		return fmt.Errorf(`write tar file=%s: %w`, name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package ccpackage_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/persistence"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/ccpackage"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func tarGzFiles(t *testing.T, archive []byte) map[string][]byte {
+	gr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatalf("read gzip: %s", err)
+	}
+
+	files := make(map[string][]byte)
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatalf("read tar: %s", err)
+		}
+		if files[header.Name], err = ioutil.ReadAll(tr); err != nil {
+			t.Fatalf("read tar file: %s", err)
+		}
+	}
+}
+
+// checkPackage parses package and stores it as peer does on install
+func checkPackage(t *testing.T, pkg []byte, expected ccpackage.Metadata) {
+	parsed, err := persistence.ChaincodePackageParser{
+		MetadataProvider: ccprovider.PersistenceAdapter(ccprovider.MetadataAsTarEntries),
+	}.Parse(pkg)
+	if err != nil {
+		t.Fatalf("parse package: %s", err)
+	}
+
+	if parsed.Metadata.Type != expected.Type || parsed.Metadata.Label != expected.Label || parsed.Metadata.Path != expected.Path {
+		t.Fatalf("metadata: expected= %+v, got= %+v", expected, parsed.Metadata)
+	}
+
+	peerPackageID, err := persistence.NewStore(t.TempDir()).Save(parsed.Metadata.Label, pkg)
+	if err != nil {
+		t.Fatalf("save package: %s", err)
+	}
+
+	packageID, err := ccpackage.ID(pkg)
+	if err != nil {
+		t.Fatalf("package id: %s", err)
+	}
+
+	if packageID != peerPackageID {
+		t.Fatalf("package id: expected= %s, got= %s", peerPackageID, packageID)
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		`package.json`: `{"name": "cc", "main": "index.js"}`,
+		`index.js`:     `console.log("chaincode")`,
+	})
+
+	pkg, err := ccpackage.New(ccpackage.TypeNode, dir, `cc_1.0`)
+	if err != nil {
+		t.Fatalf("package: %s", err)
+	}
+	checkPackage(t, pkg, ccpackage.Metadata{Path: dir, Type: ccpackage.TypeNode, Label: `cc_1.0`})
+
+	code, err := ccpackage.ReadCode(pkg)
+	if err != nil {
+		t.Fatalf("read code: %s", err)
+	}
+	if _, ok := tarGzFiles(t, code)[`src/index.js`]; !ok {
+		t.Fatal(`src/index.js not found in code package`)
+	}
+
+	if _, err = ccpackage.New(`cobol`, dir, `cc_1.0`); err == nil {
+		t.Fatal(`unknown chaincode type: expected error`)
+	}
+
+	if _, err = ccpackage.New(ccpackage.TypeNode, dir, `cc:1.0`); err == nil {
+		t.Fatal(`invalid label: expected error`)
+	}
+}
+
+func TestNew_Golang(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		`go.mod`:  "module example.com/cc\n\ngo 1.16\n",
+		`main.go`: "package main\n\nfunc main() {}\n",
+	})
+
+	pkg, err := ccpackage.New(ccpackage.TypeGolang, dir, `cc_1.0`)
+	if err != nil {
+		t.Fatalf("package: %s", err)
+	}
+	checkPackage(t, pkg, ccpackage.Metadata{Path: `example.com/cc`, Type: ccpackage.TypeGolang, Label: `cc_1.0`})
+}
+
+func TestNewService(t *testing.T) {
+	connection := ccpackage.Connection{
+		Address:     `cc.example.com:9999`,
+		DialTimeout: `10s`,
+	}
+
+	pkg, err := ccpackage.NewService(ccpackage.TypeCCaaS, `cc_1.0`, connection)
+	if err != nil {
+		t.Fatalf("package: %s", err)
+	}
+	checkPackage(t, pkg, ccpackage.Metadata{Type: ccpackage.TypeCCaaS, Label: `cc_1.0`})
+
+	code, err := ccpackage.ReadCode(pkg)
+	if err != nil {
+		t.Fatalf("read code: %s", err)
+	}
+
+	packaged := ccpackage.Connection{}
+	if err = json.Unmarshal(tarGzFiles(t, code)[ccpackage.ConnectionFile], &packaged); err != nil {
+		t.Fatalf("unmarshal connection: %s", err)
+	}
+	if packaged != connection {
+		t.Fatalf("connection: expected= %+v, got= %+v", connection, packaged)
+	}
+}
@@ -0,0 +1,197 @@
+package definition
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+)
+
+var (
+	ErrCollectionNameInvalid   = errors.New(`collection name can only consist of alphanumerics, '_' and '-' and cannot begin with '_'`)
+	ErrCollectionDuplicate     = errors.New(`duplicate collection name`)
+	ErrCollectionMembersEmpty  = errors.New(`collection member orgs or member policy required`)
+	ErrCollectionPeerCount     = errors.New(`invalid collection peer count`)
+	ErrCollectionPolicyNotOr   = errors.New(`collection member policy must be OR concatenation of principals`)
+	ErrCollectionPolicyInvalid = errors.New(`invalid collection endorsement policy`)
+
+	// the same as in _lifecycle chaincode
+	collectionNameRegExp = regexp.MustCompile(`^[A-Za-z0-9-]+([A-Za-z0-9_-]+)*$`)
+)
+
+type (
+	// Collection - private data collection definition as in collections_config.json of peer CLI
+	Collection struct {
+		Name string
+		// MemberOrgs - MSP IDs of members, member policy is OR of 'MSPID.member' principals
+		MemberOrgs []string
+		// MemberPolicy - policy DSL, used instead of MemberOrgs if set
+		MemberPolicy      string
+		RequiredPeerCount int32
+		MaxPeerCount      int32
+		// BlockToLive - number of blocks private data is kept, 0 for forever
+		BlockToLive     uint64
+		MemberOnlyRead  bool
+		MemberOnlyWrite bool
+
+		// EndorsementPolicy - collection level endorsement policy DSL, mutually exclusive with EndorsementPolicyRef
+		EndorsementPolicy string
+		// EndorsementPolicyRef - channel config policy reference for collection level endorsement
+		EndorsementPolicyRef string
+	}
+)
+
+// Collections creates collection config package for approve and commit args
+func (b *Builder) Collections(collections ...Collection) (*peer.CollectionConfigPackage, error) {
+	pkg := &peer.CollectionConfigPackage{}
+	names := make(map[string]struct{})
+
+	for _, collection := range collections {
+		if _, ok := names[collection.Name]; ok {
+			return nil, fmt.Errorf(`collection=%s: %w`, collection.Name, ErrCollectionDuplicate)
+		}
+		names[collection.Name] = struct{}{}
+
+		config, err := b.Collection(collection)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Config = append(pkg.Config, config)
+	}
+
+	return pkg, nil
+}
+
+// Collection creates static collection config, validated as _lifecycle does on approve
+func (b *Builder) Collection(collection Collection) (*peer.CollectionConfig, error) {
+	config, err := b.staticCollection(collection)
+	if err != nil {
+		return nil, fmt.Errorf(`collection=%s: %w`, collection.Name, err)
+	}
+
+	return &peer.CollectionConfig{
+		Payload: &peer.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: config},
+	}, nil
+}
+
+func (b *Builder) staticCollection(collection Collection) (*peer.StaticCollectionConfig, error) {
+	if !collectionNameRegExp.MatchString(collection.Name) {
+		return nil, ErrCollectionNameInvalid
+	}
+
+	if collection.RequiredPeerCount < 0 {
+		return nil, fmt.Errorf(`required peer count %d is negative: %w`,
+			collection.RequiredPeerCount, ErrCollectionPeerCount)
+	}
+	if collection.MaxPeerCount < collection.RequiredPeerCount {
+		return nil, fmt.Errorf(`max peer count %d is less than required peer count %d: %w`,
+			collection.MaxPeerCount, collection.RequiredPeerCount, ErrCollectionPeerCount)
+	}
+
+	memberPolicy, err := b.memberOrgsPolicy(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	endorsementPolicy, err := b.collectionEndorsementPolicy(collection)
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer.StaticCollectionConfig{
+		Name: collection.Name,
+		MemberOrgsPolicy: &peer.CollectionPolicyConfig{
+			Payload: &peer.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: memberPolicy},
+		},
+		RequiredPeerCount: collection.RequiredPeerCount,
+		MaximumPeerCount:  collection.MaxPeerCount,
+		BlockToLive:       collection.BlockToLive,
+		MemberOnlyRead:    collection.MemberOnlyRead,
+		MemberOnlyWrite:   collection.MemberOnlyWrite,
+		EndorsementPolicy: endorsementPolicy,
+	}, nil
+}
+
+func (b *Builder) memberOrgsPolicy(collection Collection) (*common.SignaturePolicyEnvelope, error) {
+	var envelope *common.SignaturePolicyEnvelope
+	switch {
+	case collection.MemberPolicy != ``:
+		var err error
+		if envelope, err = policydsl.FromString(collection.MemberPolicy); err != nil {
+			return nil, fmt.Errorf(`parse member policy=%s: %w`, collection.MemberPolicy, err)
+		}
+
+	case len(collection.MemberOrgs) > 0:
+		envelope = policydsl.SignedByAnyMember(collection.MemberOrgs)
+
+	default:
+		return nil, ErrCollectionMembersEmpty
+	}
+
+	if err := b.validateSignaturePolicy(envelope, true); err != nil {
+		return nil, fmt.Errorf(`member policy: %w`, err)
+	}
+
+	if !isOrConcatenation(envelope.Rule) {
+		return nil, ErrCollectionPolicyNotOr
+	}
+
+	return envelope, nil
+}
+
+func (b *Builder) collectionEndorsementPolicy(collection Collection) (*peer.ApplicationPolicy, error) {
+	switch {
+	case collection.EndorsementPolicy != `` && collection.EndorsementPolicyRef != ``:
+		return nil, fmt.Errorf(`both signature policy and policy ref set: %w`, ErrCollectionPolicyInvalid)
+
+	case collection.EndorsementPolicy != ``:
+		policy, err := b.EndorsementPolicy(collection.EndorsementPolicy)
+		if err != nil {
+			return nil, fmt.Errorf(`endorsement policy: %w`, err)
+		}
+		return policy, nil
+
+	case collection.EndorsementPolicyRef != ``:
+		policy, err := ChannelConfigPolicy(collection.EndorsementPolicyRef)
+		if err != nil {
+			return nil, fmt.Errorf(`endorsement policy: %w`, err)
+		}
+		return policy, nil
+	}
+
+	return nil, nil
+}
+
+// isOrConcatenation checks that each n out of rule has n = 1
+func isOrConcatenation(rule *common.SignaturePolicy) bool {
+	nOutOf := rule.GetNOutOf()
+	if nOutOf == nil {
+		return true
+	}
+	if nOutOf.N != 1 {
+		return false
+	}
+	for _, r := range nOutOf.Rules {
+		if !isOrConcatenation(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// MarshalCollections marshals collection config package, empty package is marshalled to nil
+func MarshalCollections(pkg *peer.CollectionConfigPackage) ([]byte, error) {
+	if len(pkg.GetConfig()) == 0 {
+		return nil, nil
+	}
+
+	pkgBytes, err := proto.Marshal(pkg)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal collection config package: %w`, err)
+	}
+	return pkgBytes, nil
+}
@@ -0,0 +1,90 @@
+package definition_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/policydsl"
+
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/definition"
+)
+
+func TestBuilder_Collections(t *testing.T) {
+	builder := definition.NewBuilder(`Org1MSP`, `Org2MSP`)
+
+	pkg, err := builder.Collections(definition.Collection{
+		Name:              `private`,
+		MemberOrgs:        []string{`Org1MSP`, `Org2MSP`},
+		RequiredPeerCount: 1,
+		MaxPeerCount:      3,
+		BlockToLive:       100,
+		MemberOnlyRead:    true,
+		MemberOnlyWrite:   true,
+	}, definition.Collection{
+		Name:                 `org1`,
+		MemberPolicy:         `OR('Org1MSP.member')`,
+		EndorsementPolicyRef: `/Channel/Application/Org1MSP/Endorsement`,
+	})
+	if err != nil {
+		t.Fatalf("collections: %s", err)
+	}
+
+	if len(pkg.Config) != 2 {
+		t.Fatalf("expected 2 collections, got %d", len(pkg.Config))
+	}
+
+	private := pkg.Config[0].GetStaticCollectionConfig()
+	expectedMembers := policydsl.SignedByAnyMember([]string{`Org1MSP`, `Org2MSP`})
+	if private.Name != `private` || private.RequiredPeerCount != 1 || private.MaximumPeerCount != 3 ||
+		private.BlockToLive != 100 || !private.MemberOnlyRead || !private.MemberOnlyWrite ||
+		!proto.Equal(private.MemberOrgsPolicy.GetSignaturePolicy(), expectedMembers) {
+		t.Fatalf("unexpected collection: %v", private)
+	}
+
+	if ref := pkg.Config[1].GetStaticCollectionConfig().GetEndorsementPolicy().GetChannelConfigPolicyReference(); ref != `/Channel/Application/Org1MSP/Endorsement` {
+		t.Fatalf("unexpected endorsement policy ref: %s", ref)
+	}
+
+	for name, c := range map[string]struct {
+		collections []definition.Collection
+		err         error
+	}{
+		`invalid name`: {
+			collections: []definition.Collection{{Name: `_private`, MemberOrgs: []string{`Org1MSP`}}},
+			err:         definition.ErrCollectionNameInvalid,
+		},
+		`duplicate`: {
+			collections: []definition.Collection{
+				{Name: `private`, MemberOrgs: []string{`Org1MSP`}},
+				{Name: `private`, MemberOrgs: []string{`Org2MSP`}},
+			},
+			err: definition.ErrCollectionDuplicate,
+		},
+		`no members`: {
+			collections: []definition.Collection{{Name: `private`}},
+			err:         definition.ErrCollectionMembersEmpty,
+		},
+		`unknown member`: {
+			collections: []definition.Collection{{Name: `private`, MemberOrgs: []string{`Org3MSP`}}},
+			err:         definition.ErrUnknownMSP,
+		},
+		`max less than required`: {
+			collections: []definition.Collection{{Name: `private`, MemberOrgs: []string{`Org1MSP`}, RequiredPeerCount: 2, MaxPeerCount: 1}},
+			err:         definition.ErrCollectionPeerCount,
+		},
+		`member policy not or`: {
+			collections: []definition.Collection{{Name: `private`, MemberPolicy: `AND('Org1MSP.member', 'Org2MSP.member')`}},
+			err:         definition.ErrCollectionPolicyNotOr,
+		},
+		`both endorsement policies`: {
+			collections: []definition.Collection{{Name: `private`, MemberOrgs: []string{`Org1MSP`},
+				EndorsementPolicy: `OR('Org1MSP.peer')`, EndorsementPolicyRef: definition.DefaultEndorsementPolicyRef}},
+			err: definition.ErrCollectionPolicyInvalid,
+		},
+	} {
+		if _, err = builder.Collections(c.collections...); !errors.Is(err, c.err) {
+			t.Fatalf("%s: expected= %s, got= %v", name, c.err, err)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package definition builds endorsement policies and private data collection configs
+// for _lifecycle chaincode definitions, MSP IDs used in policies are checked against channel orgs
+package definition
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+const (
+	// DefaultEndorsementPolicyRef - channel policy used by _lifecycle if validation parameter is not set
+	DefaultEndorsementPolicyRef = `/Channel/Application/Endorsement`
+
+	channelPolicyRefPrefix = `/` + channelconfig.ChannelGroupKey + `/`
+)
+
+var (
+	ErrGroupNotFound       = errors.New(`config group not found`)
+	ErrUnknownMSP          = errors.New(`msp is not a channel member`)
+	ErrPolicyRefInvalid    = errors.New(`channel config policy reference must be absolute path starting with /Channel/`)
+	ErrPrincipalNotAllowed = errors.New(`principal classification not allowed`)
+)
+
+type (
+	// Builder creates policies and collections, validating MSP IDs against channel MSP IDs.
+	// Builder without MSP IDs skips validation
+	Builder struct {
+		mspIDs map[string]struct{}
+	}
+)
+
+// NewBuilder creates builder for channel with MSP IDs
+func NewBuilder(mspIDs ...string) *Builder {
+	b := &Builder{mspIDs: make(map[string]struct{})}
+	for _, mspID := range mspIDs {
+		b.mspIDs[mspID] = struct{}{}
+	}
+	return b
+}
+
+// NewBuilderFromConfig creates builder for channel with MSP IDs of application orgs from channel config
+func NewBuilderFromConfig(config *common.Config) (*Builder, error) {
+	mspIDs, err := ApplicationMSPIDs(config)
+	if err != nil {
+		return nil, err
+	}
+	return NewBuilder(mspIDs...), nil
+}
+
+// MSPIDs returns sorted channel MSP IDs known to builder
+func (b *Builder) MSPIDs() []string {
+	mspIDs := make([]string, 0, len(b.mspIDs))
+	for mspID := range b.mspIDs {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+	return mspIDs
+}
+
+// SignaturePolicy parses policy DSL, e.g. "AND('Org1MSP.peer', OR('Org2MSP.member', 'Org3MSP.member'))"
+func (b *Builder) SignaturePolicy(dsl string) (*common.SignaturePolicyEnvelope, error) {
+	envelope, err := policydsl.FromString(dsl)
+	if err != nil {
+		return nil, fmt.Errorf(`parse policy=%s: %w`, dsl, err)
+	}
+
+	if err = b.validateSignaturePolicy(envelope, false); err != nil {
+		return nil, fmt.Errorf(`policy=%s: %w`, dsl, err)
+	}
+
+	return envelope, nil
+}
+
+// EndorsementPolicy creates application signature policy from policy DSL
+func (b *Builder) EndorsementPolicy(dsl string) (*peer.ApplicationPolicy, error) {
+	envelope, err := b.SignaturePolicy(dsl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &peer.ApplicationPolicy{
+		Type: &peer.ApplicationPolicy_SignaturePolicy{SignaturePolicy: envelope},
+	}, nil
+}
+
+// ValidationParameter creates marshalled application signature policy from policy DSL,
+// ready for ValidationParameter of approve and commit args
+func (b *Builder) ValidationParameter(dsl string) ([]byte, error) {
+	policy, err := b.EndorsementPolicy(dsl)
+	if err != nil {
+		return nil, err
+	}
+	return ValidationParameter(policy)
+}
+
+// ChannelConfigPolicy creates application policy referencing channel config policy,
+// e.g. DefaultEndorsementPolicyRef or /Channel/Application/Org1MSP/Endorsement
+func ChannelConfigPolicy(ref string) (*peer.ApplicationPolicy, error) {
+	if !strings.HasPrefix(ref, channelPolicyRefPrefix) || len(ref) == len(channelPolicyRefPrefix) {
+		return nil, fmt.Errorf(`policy ref=%s: %w`, ref, ErrPolicyRefInvalid)
+	}
+
+	return &peer.ApplicationPolicy{
+		Type: &peer.ApplicationPolicy_ChannelConfigPolicyReference{ChannelConfigPolicyReference: ref},
+	}, nil
+}
+
+// ValidationParameter marshals application policy
+func ValidationParameter(policy *peer.ApplicationPolicy) ([]byte, error) {
+	policyBytes, err := proto.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal application policy: %w`, err)
+	}
+	return policyBytes, nil
+}
+
+// ApplicationMSPIDs returns sorted MSP IDs of application orgs from channel config
+func ApplicationMSPIDs(config *common.Config) ([]string, error) {
+	if config.GetChannelGroup() == nil {
+		return nil, util.ErrConfigChannelGroupMissing
+	}
+
+	application, ok := config.ChannelGroup.Groups[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return nil, fmt.Errorf(`group=%s: %w`, channelconfig.ApplicationGroupKey, ErrGroupNotFound)
+	}
+
+	var mspIDs []string
+	for org, orgGroup := range application.Groups {
+		mspID, err := orgMSPID(orgGroup)
+		if err != nil {
+			return nil, fmt.Errorf(`org=%s: %w`, org, err)
+		}
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+
+	return mspIDs, nil
+}
+
+func orgMSPID(orgGroup *common.ConfigGroup) (string, error) {
+	value, ok := orgGroup.GetValues()[channelconfig.MSPKey]
+	if !ok {
+		return ``, fmt.Errorf(`value=%s not found`, channelconfig.MSPKey)
+	}
+
+	mspConfig := &mspproto.MSPConfig{}
+	if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+		return ``, fmt.Errorf(`unmarshal msp config: %w`, err)
+	}
+
+	if msp.ProviderType(mspConfig.Type) == msp.IDEMIX {
+		idemixMSPConfig := &mspproto.IdemixMSPConfig{}
+		if err := proto.Unmarshal(mspConfig.Config, idemixMSPConfig); err != nil {
+			return ``, fmt.Errorf(`unmarshal idemix msp config: %w`, err)
+		}
+		return idemixMSPConfig.Name, nil
+	}
+
+	fabricMSPConfig := &mspproto.FabricMSPConfig{}
+	if err := proto.Unmarshal(mspConfig.Config, fabricMSPConfig); err != nil {
+		return ``, fmt.Errorf(`unmarshal fabric msp config: %w`, err)
+	}
+	return fabricMSPConfig.Name, nil
+}
+
+// validateSignaturePolicy checks MSP IDs of role and OU principals of policy,
+// collection member orgs policy can contain only role, OU and identity principals as peer requires
+func (b *Builder) validateSignaturePolicy(envelope *common.SignaturePolicyEnvelope, memberOrgs bool) error {
+	for _, principal := range envelope.Identities {
+		var mspID string
+		switch principal.PrincipalClassification {
+		case mspproto.MSPPrincipal_ROLE:
+			role := &mspproto.MSPRole{}
+			if err := proto.Unmarshal(principal.Principal, role); err != nil {
+				return fmt.Errorf(`unmarshal msp role: %w`, err)
+			}
+			mspID = role.MspIdentifier
+
+		case mspproto.MSPPrincipal_ORGANIZATION_UNIT:
+			ou := &mspproto.OrganizationUnit{}
+			if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+				return fmt.Errorf(`unmarshal organization unit: %w`, err)
+			}
+			mspID = ou.MspIdentifier
+
+		case mspproto.MSPPrincipal_IDENTITY:
+			continue
+
+		default:
+			if memberOrgs {
+				return fmt.Errorf(`%s: %w`, principal.PrincipalClassification, ErrPrincipalNotAllowed)
+			}
+			continue
+		}
+
+		if err := b.validateMSPID(mspID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Builder) validateMSPID(mspID string) error {
+	if len(b.mspIDs) == 0 {
+		return nil
+	}
+	if _, ok := b.mspIDs[mspID]; !ok {
+		return fmt.Errorf(`msp=%s: %w`, mspID, ErrUnknownMSP)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package definition_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/definition"
+	"github.com/vitiko/hlf-sdk-go/client/chanconfig"
+)
+
+const peerMSPPath = `../../../identity/testdata/Org1MSPPeer`
+
+func TestApplicationMSPIDs(t *testing.T) {
+	application := &common.ConfigGroup{Groups: make(map[string]*common.ConfigGroup)}
+	for _, mspID := range []string{`Org2MSP`, `Org1MSP`} {
+		mspConfig, err := msp.GetVerifyingMspConfig(peerMSPPath, mspID, msp.ProviderTypeToString(msp.FABRIC))
+		if err != nil {
+			t.Fatalf("msp config: %s", err)
+		}
+
+		if application.Groups[mspID+`Group`], err = chanconfig.NewOrgGroup(mspID, mspConfig); err != nil {
+			t.Fatalf("org group: %s", err)
+		}
+	}
+
+	config := &common.Config{ChannelGroup: &common.ConfigGroup{
+		Groups: map[string]*common.ConfigGroup{channelconfig.ApplicationGroupKey: application},
+	}}
+
+	builder, err := definition.NewBuilderFromConfig(config)
+	if err != nil {
+		t.Fatalf("builder: %s", err)
+	}
+
+	if mspIDs := builder.MSPIDs(); len(mspIDs) != 2 || mspIDs[0] != `Org1MSP` || mspIDs[1] != `Org2MSP` {
+		t.Fatalf("unexpected msp ids: %v", mspIDs)
+	}
+}
+
+func TestBuilder_EndorsementPolicy(t *testing.T) {
+	builder := definition.NewBuilder(`Org1MSP`, `Org2MSP`)
+
+	validationParameter, err := builder.ValidationParameter(`AND('Org1MSP.peer', 'Org2MSP.member')`)
+	if err != nil {
+		t.Fatalf("validation parameter: %s", err)
+	}
+
+	policy := &peer.ApplicationPolicy{}
+	if err = proto.Unmarshal(validationParameter, policy); err != nil {
+		t.Fatalf("unmarshal policy: %s", err)
+	}
+
+	expected, _ := policydsl.FromString(`AND('Org1MSP.peer', 'Org2MSP.member')`)
+	if !proto.Equal(policy.GetSignaturePolicy(), expected) {
+		t.Fatalf("policy: expected= %v, got= %v", expected, policy.GetSignaturePolicy())
+	}
+
+	if _, err = builder.EndorsementPolicy(`OR('Org1MSP.member', 'Org3MSP.member')`); !errors.Is(err, definition.ErrUnknownMSP) {
+		t.Fatalf("unknown msp: expected= %s, got= %v", definition.ErrUnknownMSP, err)
+	}
+
+	if _, err = builder.EndorsementPolicy(`OR('Org1MSP.member'`); err == nil {
+		t.Fatal(`invalid dsl: expected error`)
+	}
+
+	// builder without msp ids does not validate
+	if _, err = definition.NewBuilder().EndorsementPolicy(`OR('Org3MSP.member')`); err != nil {
+		t.Fatalf("no validation: %s", err)
+	}
+}
+
+func TestChannelConfigPolicy(t *testing.T) {
+	policy, err := definition.ChannelConfigPolicy(definition.DefaultEndorsementPolicyRef)
+	if err != nil {
+		t.Fatalf("channel config policy: %s", err)
+	}
+
+	if policy.GetChannelConfigPolicyReference() != definition.DefaultEndorsementPolicyRef {
+		t.Fatalf("unexpected policy: %v", policy)
+	}
+
+	for _, ref := range []string{`Endorsement`, `/Application/Endorsement`, `/Channel/`} {
+		if _, err = definition.ChannelConfigPolicy(ref); !errors.Is(err, definition.ErrPolicyRefInvalid) {
+			t.Fatalf("ref=%s: expected= %s, got= %v", ref, definition.ErrPolicyRefInvalid, err)
+		}
+	}
+}
@@ -0,0 +1,518 @@
+// Package deploy deploys chaincode with _lifecycle: install on peers of each org, approve for each org,
+// commit and wait for committed definition. Steps already done are detected and skipped, so deploy can be repeated
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lifecycleproto "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	lifecyclecc "github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/msp"
+	"go.uber.org/zap"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/ccpackage"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/system"
+	"github.com/vitiko/hlf-sdk-go/client/tx"
+	"github.com/vitiko/hlf-sdk-go/identity"
+)
+
+const (
+	DefaultEndorsementPlugin = `escc`
+	DefaultValidationPlugin  = `vscc`
+	DefaultPollInterval      = 2 * time.Second
+)
+
+// approvedDefinitionNotFound - part of _lifecycle error message if definition is not approved for sequence
+const approvedDefinitionNotFound = `could not fetch approved chaincode definition`
+
+// Deploy steps
+const (
+	StepInstall Step = `install`
+	StepApprove Step = `approve`
+	StepCommit  Step = `commit`
+	StepWait    Step = `wait`
+)
+
+var (
+	ErrNoOrgs             = errors.New(`no orgs to deploy`)
+	ErrPackageMissing     = errors.New(`chaincode package or package id is required`)
+	ErrSequenceOutdated   = errors.New(`sequence is lower than committed`)
+	ErrDefinitionMismatch = errors.New(`committed definition differs from desired with the same sequence`)
+)
+
+type (
+	Step string
+
+	// Definition - desired chaincode definition. Empty plugins and validation parameter are set to peer defaults
+	Definition struct {
+		Channel string
+		Name    string
+		Version string
+		// Sequence - next sequence after committed is used if 0
+		Sequence            int64
+		EndorsementPlugin   string
+		ValidationPlugin    string
+		ValidationParameter []byte
+		Collections         *peer.CollectionConfigPackage
+		InitRequired        bool
+
+		// Package - chaincode install package, package ID is computed from package
+		Package []byte
+		// PackageID - ID of package, used if package is already installed on all peers and Package is not set
+		PackageID string
+	}
+
+	// Org - org admin client and org peers for install
+	Org struct {
+		// Admin invoker is used for approve and commit, admin identity is used for install on peers
+		Admin api.Invoker
+		// AdminIdentity signs install and approve requests, Admin invoker identity is used if not set
+		AdminIdentity msp.SigningIdentity
+		Peers         []Peer
+	}
+
+	// Peer - querier of org peer, implemented by api.Peer
+	Peer interface {
+		api.Querier
+		Uri() string
+	}
+
+	// Event - progress of deploy
+	Event struct {
+		Step Step
+		// MSPID of org
+		MSPID string
+		// Peer uri for install step
+		Peer string
+		// Skipped is true if step was already done
+		Skipped bool
+	}
+
+	// Result - deployed definition
+	Result struct {
+		PackageID string
+		Sequence  int64
+		Events    []Event
+	}
+
+	Deployer struct {
+		orgs         []Org
+		pollInterval time.Duration
+		progress     func(Event)
+		logger       *zap.Logger
+	}
+
+	Opt func(*Deployer)
+)
+
+// WithPollInterval sets interval of commit readiness and committed definition checks
+func WithPollInterval(interval time.Duration) Opt {
+	return func(d *Deployer) {
+		d.pollInterval = interval
+	}
+}
+
+// WithProgress sets callback called after each step
+func WithProgress(progress func(Event)) Opt {
+	return func(d *Deployer) {
+		d.progress = progress
+	}
+}
+
+func WithLogger(logger *zap.Logger) Opt {
+	return func(d *Deployer) {
+		d.logger = logger
+	}
+}
+
+// NewOrg creates org with admin identity of MSP (identity from 'admincerts' or with admin NodeOU)
+// for install and approve
+func NewOrg(admin api.Invoker, mspConfig identity.MSPWithRoles, cs api.CryptoSuite, peers ...Peer) (Org, error) {
+	adminIdentity, err := mspConfig.Admin()
+	if err != nil {
+		return Org{}, fmt.Errorf(`msp=%s admin: %w`, mspConfig.GetMSPIdentifier(), err)
+	}
+
+	return Org{
+		Admin:         admin,
+		AdminIdentity: adminIdentity.GetSigningIdentity(cs),
+		Peers:         peers,
+	}, nil
+}
+
+// New creates deployer, first org is used for commit
+func New(orgs []Org, opts ...Opt) *Deployer {
+	d := &Deployer{
+		orgs:         orgs,
+		pollInterval: DefaultPollInterval,
+		logger:       zap.NewNop(),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Deploy brings chaincode definition on channel to desired state
+func (d *Deployer) Deploy(ctx context.Context, definition Definition) (*Result, error) {
+	if len(d.orgs) == 0 {
+		return nil, ErrNoOrgs
+	}
+
+	definition = withDefaults(definition)
+
+	packageID := definition.PackageID
+	if len(definition.Package) > 0 {
+		var err error
+		if packageID, err = ccpackage.ID(definition.Package); err != nil {
+			return nil, fmt.Errorf(`package id: %w`, err)
+		}
+	}
+	if packageID == `` {
+		return nil, ErrPackageMissing
+	}
+
+	result := &Result{PackageID: packageID}
+	report := func(event Event) {
+		result.Events = append(result.Events, event)
+		d.logger.Info(`chaincode deploy`,
+			zap.String(`channel`, definition.Channel),
+			zap.String(`chaincode`, definition.Name),
+			zap.String(`step`, string(event.Step)),
+			zap.String(`msp_id`, event.MSPID),
+			zap.String(`peer`, event.Peer),
+			zap.Bool(`skipped`, event.Skipped))
+		if d.progress != nil {
+			d.progress(event)
+		}
+	}
+
+	committed, err := d.committedDefinition(ctx, d.orgs[0], definition)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Sequence, err = nextSequence(definition, committed); err != nil {
+		return nil, err
+	}
+
+	for _, org := range d.orgs {
+		if err = d.install(ctx, org, definition, packageID, report); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, org := range d.orgs {
+		if err = d.approve(ctx, org, definition, packageID, result.Sequence, report); err != nil {
+			return nil, err
+		}
+	}
+
+	if committed != nil && committed.Sequence == result.Sequence {
+		report(Event{Step: StepCommit, MSPID: mspID(d.orgs[0]), Skipped: true})
+		return result, nil
+	}
+
+	if err = d.commit(ctx, definition, result.Sequence, report); err != nil {
+		return nil, err
+	}
+
+	for _, org := range d.orgs {
+		if err = d.waitCommitted(ctx, org, definition, result.Sequence); err != nil {
+			return nil, err
+		}
+		report(Event{Step: StepWait, MSPID: mspID(org)})
+	}
+
+	return result, nil
+}
+
+// committedDefinition returns committed definition of chaincode or nil if chaincode is not committed yet
+func (d *Deployer) committedDefinition(ctx context.Context, org Org, definition Definition) (
+	*lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition, error) {
+
+	definitions, err := system.NewLifecycle(org.Admin).QueryChaincodeDefinitions(ctx,
+		&system.QueryChaincodeDefinitionsRequest{
+			Channel: definition.Channel,
+			Args:    &lifecycleproto.QueryChaincodeDefinitionsArgs{},
+		})
+	if err != nil {
+		return nil, fmt.Errorf(`query committed definitions: %w`, err)
+	}
+
+	for _, committed := range definitions.ChaincodeDefinitions {
+		if committed.Name == definition.Name {
+			return committed, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (d *Deployer) install(ctx context.Context, org Org, definition Definition, packageID string, report func(Event)) error {
+	// install requires org admin identity
+	ctx = tx.ContextWithSigner(ctx, adminIdentity(org))
+
+	for _, p := range org.Peers {
+		res, err := tx.QueryProto(ctx, p, ``, system.LifecycleName,
+			[]interface{}{lifecyclecc.QueryInstalledChaincodesFuncName, &lifecycleproto.QueryInstalledChaincodesArgs{}},
+			&lifecycleproto.QueryInstalledChaincodesResult{})
+		if err != nil {
+			return fmt.Errorf(`query installed chaincodes on peer=%s: %w`, p.Uri(), err)
+		}
+
+		if isInstalled(res.(*lifecycleproto.QueryInstalledChaincodesResult), packageID) {
+			report(Event{Step: StepInstall, MSPID: mspID(org), Peer: p.Uri(), Skipped: true})
+			continue
+		}
+
+		if len(definition.Package) == 0 {
+			return fmt.Errorf(`package id=%s is not installed on peer=%s: %w`, packageID, p.Uri(), ErrPackageMissing)
+		}
+
+		if _, err = tx.QueryProto(ctx, p, ``, system.LifecycleName,
+			[]interface{}{lifecyclecc.InstallChaincodeFuncName, &lifecycleproto.InstallChaincodeArgs{
+				ChaincodeInstallPackage: definition.Package,
+			}},
+			&lifecycleproto.InstallChaincodeResult{}); err != nil {
+			return fmt.Errorf(`install on peer=%s: %w`, p.Uri(), err)
+		}
+
+		report(Event{Step: StepInstall, MSPID: mspID(org), Peer: p.Uri()})
+	}
+
+	return nil
+}
+
+func (d *Deployer) approve(
+	ctx context.Context, org Org, definition Definition, packageID string, sequence int64, report func(Event)) error {
+
+	// approve requires org admin identity
+	ctx = tx.ContextWithSigner(ctx, adminIdentity(org))
+	lifecycle := system.NewLifecycle(org.Admin)
+
+	approved, err := lifecycle.QueryApprovedChaincodeDefinition(ctx, &system.QueryApprovedChaincodeDefinitionRequest{
+		Channel: definition.Channel,
+		Args: &lifecycleproto.QueryApprovedChaincodeDefinitionArgs{
+			Name:     definition.Name,
+			Sequence: sequence,
+		},
+	})
+	// not found error is returned by peer if definition is not approved for sequence
+	if err != nil && !strings.Contains(err.Error(), approvedDefinitionNotFound) {
+		return fmt.Errorf(`query approved definition for msp=%s: %w`, mspID(org), err)
+	}
+	if err == nil && isApproved(approved, definition, packageID) {
+		report(Event{Step: StepApprove, MSPID: mspID(org), Skipped: true})
+		return nil
+	}
+
+	if _, err = lifecycle.ApproveChaincodeDefinitionForMyOrg(ctx, &system.ApproveChaincodeDefinitionForMyOrgRequest{
+		Channel: definition.Channel,
+		Args: &lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs{
+			Sequence:            sequence,
+			Name:                definition.Name,
+			Version:             definition.Version,
+			EndorsementPlugin:   definition.EndorsementPlugin,
+			ValidationPlugin:    definition.ValidationPlugin,
+			ValidationParameter: definition.ValidationParameter,
+			Collections:         definition.Collections,
+			InitRequired:        definition.InitRequired,
+			Source: &lifecycleproto.ChaincodeSource{
+				Type: &lifecycleproto.ChaincodeSource_LocalPackage{
+					LocalPackage: &lifecycleproto.ChaincodeSource_Local{PackageId: packageID},
+				},
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf(`approve for msp=%s: %w`, mspID(org), err)
+	}
+
+	report(Event{Step: StepApprove, MSPID: mspID(org)})
+	return nil
+}
+
+func (d *Deployer) commit(ctx context.Context, definition Definition, sequence int64, report func(Event)) error {
+	org := d.orgs[0]
+	// approve requires org admin identity
+	ctx = tx.ContextWithSigner(ctx, adminIdentity(org))
+	lifecycle := system.NewLifecycle(org.Admin)
+
+	var endorserMSPs []string
+	for _, o := range d.orgs {
+		endorserMSPs = append(endorserMSPs, mspID(o))
+	}
+
+	// approvals are committed in blocks asynchronously, so commit readiness is polled until all orgs approved
+	for {
+		readiness, err := lifecycle.CheckCommitReadiness(ctx, &system.CheckCommitReadinessRequest{
+			Channel: definition.Channel,
+			Args: &lifecycleproto.CheckCommitReadinessArgs{
+				Sequence:            sequence,
+				Name:                definition.Name,
+				Version:             definition.Version,
+				EndorsementPlugin:   definition.EndorsementPlugin,
+				ValidationPlugin:    definition.ValidationPlugin,
+				ValidationParameter: definition.ValidationParameter,
+				Collections:         definition.Collections,
+				InitRequired:        definition.InitRequired,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf(`check commit readiness: %w`, err)
+		}
+
+		if allApproved(readiness, endorserMSPs) {
+			break
+		}
+
+		d.logger.Debug(`waiting for approvals`, zap.Reflect(`approvals`, readiness.Approvals))
+		if err = d.sleep(ctx); err != nil {
+			return err
+		}
+	}
+
+	if _, err := lifecycle.CommitChaincodeDefinition(tx.ContextWithEndorserMSPs(ctx, endorserMSPs),
+		&system.CommitChaincodeDefinitionRequest{
+			Channel: definition.Channel,
+			Args: &lifecycleproto.CommitChaincodeDefinitionArgs{
+				Sequence:            sequence,
+				Name:                definition.Name,
+				Version:             definition.Version,
+				EndorsementPlugin:   definition.EndorsementPlugin,
+				ValidationPlugin:    definition.ValidationPlugin,
+				ValidationParameter: definition.ValidationParameter,
+				Collections:         definition.Collections,
+				InitRequired:        definition.InitRequired,
+			},
+		}); err != nil {
+		return fmt.Errorf(`commit: %w`, err)
+	}
+
+	report(Event{Step: StepCommit, MSPID: mspID(org)})
+	return nil
+}
+
+// waitCommitted waits until org peers have committed definition with sequence
+func (d *Deployer) waitCommitted(ctx context.Context, org Org, definition Definition, sequence int64) error {
+	for {
+		committed, err := d.committedDefinition(ctx, org, definition)
+		if err != nil {
+			return err
+		}
+
+		if committed != nil && committed.Sequence >= sequence {
+			return nil
+		}
+
+		if err = d.sleep(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *Deployer) sleep(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d.pollInterval):
+		return nil
+	}
+}
+
+// withDefaults sets empty fields to values used by peer, so definitions can be compared with queried ones
+func withDefaults(definition Definition) Definition {
+	if definition.EndorsementPlugin == `` {
+		definition.EndorsementPlugin = DefaultEndorsementPlugin
+	}
+	if definition.ValidationPlugin == `` {
+		definition.ValidationPlugin = DefaultValidationPlugin
+	}
+	if len(definition.ValidationParameter) == 0 {
+		definition.ValidationParameter = lifecyclecc.DefaultEndorsementPolicyBytes
+	}
+	return definition
+}
+
+// nextSequence returns sequence of desired definition: committed sequence if committed definition
+// is the same as desired, otherwise next sequence
+func nextSequence(definition Definition, committed *lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition) (int64, error) {
+	var committedSequence int64
+	if committed != nil {
+		committedSequence = committed.Sequence
+	}
+
+	sameAsCommitted := committed != nil &&
+		committed.Version == definition.Version &&
+		committed.EndorsementPlugin == definition.EndorsementPlugin &&
+		committed.ValidationPlugin == definition.ValidationPlugin &&
+		string(committed.ValidationParameter) == string(definition.ValidationParameter) &&
+		sameCollections(committed.Collections, definition.Collections) &&
+		committed.InitRequired == definition.InitRequired
+
+	switch {
+	case definition.Sequence == 0 && sameAsCommitted:
+		return committedSequence, nil
+	case definition.Sequence == 0:
+		return committedSequence + 1, nil
+	case definition.Sequence < committedSequence:
+		return 0, fmt.Errorf(`sequence=%d, committed=%d: %w`, definition.Sequence, committedSequence, ErrSequenceOutdated)
+	case definition.Sequence == committedSequence && !sameAsCommitted:
+		return 0, fmt.Errorf(`sequence=%d: %w`, definition.Sequence, ErrDefinitionMismatch)
+	default:
+		return definition.Sequence, nil
+	}
+}
+
+func isInstalled(installed *lifecycleproto.QueryInstalledChaincodesResult, packageID string) bool {
+	for _, cc := range installed.InstalledChaincodes {
+		if cc.PackageId == packageID {
+			return true
+		}
+	}
+	return false
+}
+
+func isApproved(approved *lifecycleproto.QueryApprovedChaincodeDefinitionResult, definition Definition, packageID string) bool {
+	return approved.Version == definition.Version &&
+		approved.EndorsementPlugin == definition.EndorsementPlugin &&
+		approved.ValidationPlugin == definition.ValidationPlugin &&
+		string(approved.ValidationParameter) == string(definition.ValidationParameter) &&
+		sameCollections(approved.Collections, definition.Collections) &&
+		approved.InitRequired == definition.InitRequired &&
+		approved.GetSource().GetLocalPackage().GetPackageId() == packageID
+}
+
+func allApproved(readiness *lifecycleproto.CheckCommitReadinessResult, mspIDs []string) bool {
+	for _, mspID := range mspIDs {
+		if !readiness.Approvals[mspID] {
+			return false
+		}
+	}
+	return true
+}
+
+// sameCollections compares collections, nil and empty collection packages are equal
+func sameCollections(a, b *peer.CollectionConfigPackage) bool {
+	return len(a.GetConfig()) == len(b.GetConfig()) && (len(a.GetConfig()) == 0 || proto.Equal(a, b))
+}
+
+func adminIdentity(org Org) msp.SigningIdentity {
+	if org.AdminIdentity != nil {
+		return org.AdminIdentity
+	}
+	return org.Admin.CurrentIdentity()
+}
+
+func mspID(org Org) string {
+	return org.Admin.CurrentIdentity().GetMSPIdentifier()
+}
@@ -0,0 +1,357 @@
+package deploy_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lifecycleproto "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	lifecyclecc "github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/ccpackage"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/deploy"
+	"github.com/vitiko/hlf-sdk-go/client/tx"
+)
+
+type (
+	identity struct {
+		msp.SigningIdentity
+		mspID string
+	}
+
+	// channelLifecycle - in-memory _lifecycle state of channel
+	channelLifecycle struct {
+		mu        sync.Mutex
+		approved  map[string]map[int64]*lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs
+		committed map[string]*lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition
+		commits   int
+	}
+
+	orgAdmin struct {
+		identity identity
+		channel  *channelLifecycle
+		// queryApprovedErr is returned on approved definition query if set
+		queryApprovedErr error
+		approveSigners   []msp.SigningIdentity
+	}
+
+	fakePeer struct {
+		uri            string
+		identity       identity
+		installed      map[string]bool
+		installSigners []msp.SigningIdentity
+	}
+)
+
+func (i identity) GetMSPIdentifier() string {
+	return i.mspID
+}
+
+func response(msg proto.Message) (*peer.Response, error) {
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &peer.Response{Status: 200, Payload: payload}, nil
+}
+
+func (a *orgAdmin) CurrentIdentity() msp.SigningIdentity {
+	return a.identity
+}
+
+func (a *orgAdmin) Query(_ context.Context, _, _ string, args [][]byte, _ msp.SigningIdentity, _ map[string][]byte) (*peer.Response, error) {
+	c := a.channel
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch string(args[0]) {
+	case lifecyclecc.QueryChaincodeDefinitionsFuncName:
+		result := &lifecycleproto.QueryChaincodeDefinitionsResult{}
+		for _, definition := range c.committed {
+			result.ChaincodeDefinitions = append(result.ChaincodeDefinitions, definition)
+		}
+		return response(result)
+
+	case lifecyclecc.QueryApprovedChaincodeDefinitionFuncName:
+		if a.queryApprovedErr != nil {
+			return nil, a.queryApprovedErr
+		}
+		queryArgs := &lifecycleproto.QueryApprovedChaincodeDefinitionArgs{}
+		if err := proto.Unmarshal(args[1], queryArgs); err != nil {
+			return nil, err
+		}
+		approved, ok := c.approved[a.identity.mspID][queryArgs.Sequence]
+		if !ok {
+			return nil, fmt.Errorf(`could not fetch approved chaincode definition (name: '%s', sequence: '%d')`,
+				queryArgs.Name, queryArgs.Sequence)
+		}
+		return response(&lifecycleproto.QueryApprovedChaincodeDefinitionResult{
+			Sequence:            approved.Sequence,
+			Version:             approved.Version,
+			EndorsementPlugin:   approved.EndorsementPlugin,
+			ValidationPlugin:    approved.ValidationPlugin,
+			ValidationParameter: approved.ValidationParameter,
+			Collections:         approved.Collections,
+			InitRequired:        approved.InitRequired,
+			Source:              approved.Source,
+		})
+
+	case lifecyclecc.CheckCommitReadinessFuncName:
+		readinessArgs := &lifecycleproto.CheckCommitReadinessArgs{}
+		if err := proto.Unmarshal(args[1], readinessArgs); err != nil {
+			return nil, err
+		}
+		result := &lifecycleproto.CheckCommitReadinessResult{Approvals: map[string]bool{}}
+		for mspID, approvals := range c.approved {
+			approved, ok := approvals[readinessArgs.Sequence]
+			result.Approvals[mspID] = ok && approved.Version == readinessArgs.Version
+		}
+		return response(result)
+	}
+
+	return nil, fmt.Errorf(`unexpected query: %s`, args[0])
+}
+
+func (a *orgAdmin) Invoke(ctx context.Context, _, _ string, args [][]byte, _ msp.SigningIdentity, _ map[string][]byte, _ string) (*peer.Response, string, error) {
+	c := a.channel
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch string(args[0]) {
+	case lifecyclecc.ApproveChaincodeDefinitionForMyOrgFuncName:
+		approveArgs := &lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs{}
+		if err := proto.Unmarshal(args[1], approveArgs); err != nil {
+			return nil, ``, err
+		}
+		if c.approved[a.identity.mspID] == nil {
+			c.approved[a.identity.mspID] = make(map[int64]*lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs)
+		}
+		c.approved[a.identity.mspID][approveArgs.Sequence] = approveArgs
+		a.approveSigners = append(a.approveSigners, tx.SignerFromContext(ctx))
+		return &peer.Response{Status: 200}, `tx`, nil
+
+	case lifecyclecc.CommitChaincodeDefinitionFuncName:
+		commitArgs := &lifecycleproto.CommitChaincodeDefinitionArgs{}
+		if err := proto.Unmarshal(args[1], commitArgs); err != nil {
+			return nil, ``, err
+		}
+		c.committed[commitArgs.Name] = &lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition{
+			Name:                commitArgs.Name,
+			Sequence:            commitArgs.Sequence,
+			Version:             commitArgs.Version,
+			EndorsementPlugin:   commitArgs.EndorsementPlugin,
+			ValidationPlugin:    commitArgs.ValidationPlugin,
+			ValidationParameter: commitArgs.ValidationParameter,
+			Collections:         commitArgs.Collections,
+			InitRequired:        commitArgs.InitRequired,
+		}
+		c.commits++
+		res, err := response(&lifecycleproto.CommitChaincodeDefinitionResult{})
+		return res, `tx`, err
+	}
+
+	return nil, ``, fmt.Errorf(`unexpected invoke: %s`, args[0])
+}
+
+func (p *fakePeer) CurrentIdentity() msp.SigningIdentity {
+	return p.identity
+}
+
+func (p *fakePeer) Uri() string {
+	return p.uri
+}
+
+func (p *fakePeer) Query(ctx context.Context, _, _ string, args [][]byte, _ msp.SigningIdentity, _ map[string][]byte) (*peer.Response, error) {
+	switch string(args[0]) {
+	case lifecyclecc.QueryInstalledChaincodesFuncName:
+		result := &lifecycleproto.QueryInstalledChaincodesResult{}
+		for packageID := range p.installed {
+			result.InstalledChaincodes = append(result.InstalledChaincodes,
+				&lifecycleproto.QueryInstalledChaincodesResult_InstalledChaincode{PackageId: packageID})
+		}
+		return response(result)
+
+	case lifecyclecc.InstallChaincodeFuncName:
+		installArgs := &lifecycleproto.InstallChaincodeArgs{}
+		if err := proto.Unmarshal(args[1], installArgs); err != nil {
+			return nil, err
+		}
+		packageID, err := ccpackage.ID(installArgs.ChaincodeInstallPackage)
+		if err != nil {
+			return nil, err
+		}
+		p.installed[packageID] = true
+		p.installSigners = append(p.installSigners, tx.SignerFromContext(ctx))
+		return response(&lifecycleproto.InstallChaincodeResult{PackageId: packageID})
+	}
+
+	return nil, fmt.Errorf(`unexpected query: %s`, args[0])
+}
+
+func ccPackage(t *testing.T, label string) []byte {
+	pkg, err := ccpackage.NewService(ccpackage.TypeCCaaS, label, ccpackage.Connection{Address: `cc:9999`})
+	if err != nil {
+		t.Fatalf("package: %s", err)
+	}
+	return pkg
+}
+
+func countSteps(events []deploy.Event) map[deploy.Step]int {
+	steps := make(map[deploy.Step]int)
+	for _, event := range events {
+		if !event.Skipped {
+			steps[event.Step]++
+		}
+	}
+	return steps
+}
+
+func TestDeployer_Deploy(t *testing.T) {
+	channel := &channelLifecycle{
+		approved:  make(map[string]map[int64]*lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs),
+		committed: make(map[string]*lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition),
+	}
+
+	var orgs []deploy.Org
+	for i, peersCount := range []int{2, 1} {
+		id := identity{mspID: fmt.Sprintf(`Org%dMSP`, i+1)}
+		org := deploy.Org{Admin: &orgAdmin{identity: id, channel: channel}}
+		for j := 0; j < peersCount; j++ {
+			org.Peers = append(org.Peers, &fakePeer{
+				uri:       fmt.Sprintf(`peer%d.org%d:7051`, j, i+1),
+				identity:  id,
+				installed: make(map[string]bool),
+			})
+		}
+		orgs = append(orgs, org)
+	}
+
+	var progress []deploy.Event
+	deployer := deploy.New(orgs,
+		deploy.WithPollInterval(time.Millisecond),
+		deploy.WithProgress(func(event deploy.Event) {
+			progress = append(progress, event)
+		}))
+
+	ctx := context.Background()
+	definition := deploy.Definition{
+		Channel: `channel`,
+		Name:    `cc`,
+		Version: `1.0`,
+		Package: ccPackage(t, `cc_1.0`),
+	}
+
+	result, err := deployer.Deploy(ctx, definition)
+	if err != nil {
+		t.Fatalf("deploy: %s", err)
+	}
+
+	expectedID, _ := ccpackage.ID(definition.Package)
+	if result.PackageID != expectedID || result.Sequence != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	steps := countSteps(result.Events)
+	if steps[deploy.StepInstall] != 3 || steps[deploy.StepApprove] != 2 || steps[deploy.StepCommit] != 1 || steps[deploy.StepWait] != 2 {
+		t.Fatalf("unexpected steps: %v", steps)
+	}
+	if len(progress) != len(result.Events) {
+		t.Fatalf("progress events: expected= %d, got= %d", len(result.Events), len(progress))
+	}
+
+	// repeated deploy does nothing
+	if result, err = deployer.Deploy(ctx, definition); err != nil {
+		t.Fatalf("repeated deploy: %s", err)
+	}
+	if steps = countSteps(result.Events); len(steps) != 0 || result.Sequence != 1 || channel.commits != 1 {
+		t.Fatalf("repeated deploy: unexpected steps: %v, sequence: %d", steps, result.Sequence)
+	}
+
+	// upgrade
+	upgrade := definition
+	upgrade.Version = `2.0`
+	upgrade.Package = ccPackage(t, `cc_2.0`)
+
+	if result, err = deployer.Deploy(ctx, upgrade); err != nil {
+		t.Fatalf("upgrade: %s", err)
+	}
+	if result.Sequence != 2 || channel.committed[`cc`].Version != `2.0` || channel.commits != 2 {
+		t.Fatalf("upgrade: unexpected result: %+v", result)
+	}
+
+	// definition with committed sequence must be the same as committed
+	mismatch := definition
+	mismatch.Sequence = 2
+	if _, err = deployer.Deploy(ctx, mismatch); !errors.Is(err, deploy.ErrDefinitionMismatch) {
+		t.Fatalf("mismatch: expected= %s, got= %v", deploy.ErrDefinitionMismatch, err)
+	}
+
+	mismatch.Sequence = 1
+	if _, err = deployer.Deploy(ctx, mismatch); !errors.Is(err, deploy.ErrSequenceOutdated) {
+		t.Fatalf("outdated: expected= %s, got= %v", deploy.ErrSequenceOutdated, err)
+	}
+}
+
+func TestDeployer_ApproveQueryError(t *testing.T) {
+	channel := &channelLifecycle{
+		approved:  make(map[string]map[int64]*lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs),
+		committed: make(map[string]*lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition),
+	}
+
+	id := identity{mspID: `Org1MSP`}
+	queryErr := errors.New(`access denied`)
+	deployer := deploy.New([]deploy.Org{{
+		Admin: &orgAdmin{identity: id, channel: channel, queryApprovedErr: queryErr},
+		Peers: []deploy.Peer{&fakePeer{uri: `peer0.org1:7051`, identity: id, installed: make(map[string]bool)}},
+	}}, deploy.WithPollInterval(time.Millisecond))
+
+	_, err := deployer.Deploy(context.Background(), deploy.Definition{
+		Channel: `channel`,
+		Name:    `cc`,
+		Version: `1.0`,
+		Package: ccPackage(t, `cc_1.0`),
+	})
+	if !errors.Is(err, queryErr) {
+		t.Fatalf("deploy: expected= %s, got= %v", queryErr, err)
+	}
+	if len(channel.approved) != 0 {
+		t.Fatalf("approvals: expected= 0, got= %d", len(channel.approved))
+	}
+}
+
+func TestDeployer_AdminIdentity(t *testing.T) {
+	channel := &channelLifecycle{
+		approved:  make(map[string]map[int64]*lifecycleproto.ApproveChaincodeDefinitionForMyOrgArgs),
+		committed: make(map[string]*lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition),
+	}
+
+	id := identity{mspID: `Org1MSP`}
+	adminIdentity := &identity{mspID: `Org1MSP`}
+	admin := &orgAdmin{identity: id, channel: channel}
+	p := &fakePeer{uri: `peer0.org1:7051`, identity: id, installed: make(map[string]bool)}
+
+	deployer := deploy.New([]deploy.Org{{Admin: admin, AdminIdentity: adminIdentity, Peers: []deploy.Peer{p}}},
+		deploy.WithPollInterval(time.Millisecond))
+
+	if _, err := deployer.Deploy(context.Background(), deploy.Definition{
+		Channel: `channel`,
+		Name:    `cc`,
+		Version: `1.0`,
+		Package: ccPackage(t, `cc_1.0`),
+	}); err != nil {
+		t.Fatalf("deploy: %s", err)
+	}
+
+	if len(p.installSigners) != 1 || p.installSigners[0] != adminIdentity {
+		t.Fatalf("install signers: expected= admin identity, got= %v", p.installSigners)
+	}
+	if len(admin.approveSigners) != 1 || admin.approveSigners[0] != adminIdentity {
+		t.Fatalf("approve signers: expected= admin identity, got= %v", admin.approveSigners)
+	}
+}
@@ -0,0 +1,264 @@
+// Package migrate plans migration of chaincodes instantiated with lscc to _lifecycle definitions.
+// Plan is executed with deploy.Deployer after V2_0 application capability is enabled on channel
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lifecycleproto "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/channelconfig"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/deploy"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/system"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+// Step actions
+const (
+	// ActionDeploy - chaincode is deployed with _lifecycle with translated definition
+	ActionDeploy Action = `deploy`
+	// ActionSkip - chaincode already has _lifecycle definition
+	ActionSkip Action = `skip`
+)
+
+var (
+	ErrCapabilityNotEnabled = errors.New(`application capability V2_0 is not enabled`)
+	ErrPackageMissing       = errors.New(`_lifecycle package for chaincode is missing`)
+)
+
+type (
+	Action string
+
+	// Chaincode - chaincode instantiated with lscc
+	Chaincode struct {
+		Data        *peer.ChaincodeData
+		Collections *peer.CollectionConfigPackage
+	}
+
+	// Step - migration of one chaincode
+	Step struct {
+		Chaincode string
+		Action    Action
+		// Label - suggested label of _lifecycle package, lscc packages can't be installed with _lifecycle
+		Label string
+		// Definition - translated definition, Package or PackageID must be set before deploy
+		Definition deploy.Definition
+		// Warnings - parts of lscc definition without _lifecycle equivalent
+		Warnings []string
+	}
+
+	Plan struct {
+		Channel string
+		// CapabilityEnabled is true if V2_0 application capability is enabled on channel at planning time
+		CapabilityEnabled bool
+		Steps             []Step
+	}
+
+	// Planner queries lscc, _lifecycle and channel config with invoker of channel member
+	Planner struct {
+		lscc      *system.LSCCService
+		lifecycle *system.LifecycleService
+		cscc      *system.CSCCService
+	}
+)
+
+func NewPlanner(invoker api.Invoker) *Planner {
+	return &Planner{
+		lscc:      system.NewLSCC(invoker),
+		lifecycle: system.NewLifecycle(invoker),
+		cscc:      system.NewCSCC(invoker, hlfproto.FabricV2),
+	}
+}
+
+// Plan lists chaincodes instantiated with lscc on channel and translates them to _lifecycle definitions
+func (p *Planner) Plan(ctx context.Context, channel string) (*Plan, error) {
+	config, err := p.cscc.GetChannelConfig(ctx, &system.GetChannelConfigRequest{Channel: channel})
+	if err != nil {
+		return nil, fmt.Errorf(`get channel config: %w`, err)
+	}
+
+	chaincodes, err := p.lscc.GetChaincodes(ctx, &system.GetChaincodesRequest{Channel: channel})
+	if err != nil {
+		return nil, fmt.Errorf(`get lscc chaincodes: %w`, err)
+	}
+
+	definitions, err := p.lifecycle.QueryChaincodeDefinitions(ctx, &system.QueryChaincodeDefinitionsRequest{
+		Channel: channel,
+		Args:    &lifecycleproto.QueryChaincodeDefinitionsArgs{},
+	})
+	if err != nil {
+		return nil, fmt.Errorf(`query _lifecycle definitions: %w`, err)
+	}
+
+	committed := make(map[string]bool)
+	for _, definition := range definitions.ChaincodeDefinitions {
+		committed[definition.Name] = true
+	}
+
+	plan := &Plan{
+		Channel:           channel,
+		CapabilityEnabled: applicationV20Enabled(config),
+	}
+
+	for _, info := range chaincodes.Chaincodes {
+		data, err := p.lscc.GetChaincodeData(ctx, &system.GetChaincodeDataRequest{Channel: channel, Chaincode: info.Name})
+		if err != nil {
+			return nil, fmt.Errorf(`get lscc chaincode=%s data: %w`, info.Name, err)
+		}
+
+		collections, err := p.lscc.GetCollectionsConfig(ctx, channel, info.Name)
+		if err != nil {
+			return nil, fmt.Errorf(`get lscc chaincode=%s collections: %w`, info.Name, err)
+		}
+
+		step, err := NewStep(channel, Chaincode{Data: data, Collections: collections})
+		if err != nil {
+			return nil, err
+		}
+
+		if committed[info.Name] {
+			step.Action = ActionSkip
+		}
+
+		plan.Steps = append(plan.Steps, *step)
+	}
+
+	return plan, nil
+}
+
+// NewStep translates lscc chaincode definition to _lifecycle definition with sequence 1
+func NewStep(channel string, chaincode Chaincode) (*Step, error) {
+	data := chaincode.Data
+	step := &Step{
+		Chaincode: data.Name,
+		Action:    ActionDeploy,
+		Label:     data.Name + `_` + data.Version,
+		Definition: deploy.Definition{
+			Channel:           channel,
+			Name:              data.Name,
+			Version:           data.Version,
+			Sequence:          1,
+			EndorsementPlugin: data.Escc,
+			ValidationPlugin:  data.Vscc,
+		},
+	}
+
+	if data.Policy != nil {
+		policyBytes, err := proto.Marshal(&peer.ApplicationPolicy{
+			Type: &peer.ApplicationPolicy_SignaturePolicy{SignaturePolicy: data.Policy},
+		})
+		if err != nil {
+			return nil, fmt.Errorf(`chaincode=%s: marshal endorsement policy: %w`, data.Name, err)
+		}
+		step.Definition.ValidationParameter = policyBytes
+	} else {
+		step.warn(`endorsement policy is not set, channel default endorsement policy will be used`)
+	}
+
+	if len(chaincode.Collections.GetConfig()) > 0 {
+		step.Definition.Collections = chaincode.Collections
+	}
+
+	if data.Escc != `` && data.Escc != deploy.DefaultEndorsementPlugin {
+		step.warn(`custom endorsement plugin %s must be configured on peers`, data.Escc)
+	}
+	if data.Vscc != `` && data.Vscc != deploy.DefaultValidationPlugin {
+		step.warn(`custom validation plugin %s must be configured on peers`, data.Vscc)
+	}
+	if data.InstantiationPolicy != nil {
+		step.warn(`instantiation policy is not supported by _lifecycle, definition is approved by channel orgs instead`)
+	}
+
+	return step, nil
+}
+
+// Pending returns steps to execute
+func (p *Plan) Pending() []Step {
+	var steps []Step
+	for _, step := range p.Steps {
+		if step.Action == ActionDeploy {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// Execute deploys pending steps with packages by chaincode name. Deploy is idempotent,
+// so failed execution can be repeated with the same plan
+func (p *Plan) Execute(ctx context.Context, deployer *deploy.Deployer, packages map[string][]byte) ([]*deploy.Result, error) {
+	if !p.CapabilityEnabled {
+		return nil, fmt.Errorf(`channel=%s: %w`, p.Channel, ErrCapabilityNotEnabled)
+	}
+
+	pending := p.Pending()
+	for _, step := range pending {
+		if len(packages[step.Chaincode]) == 0 && step.Definition.PackageID == `` {
+			return nil, fmt.Errorf(`chaincode=%s: %w`, step.Chaincode, ErrPackageMissing)
+		}
+	}
+
+	var results []*deploy.Result
+	for _, step := range pending {
+		definition := step.Definition
+		if pkg, ok := packages[step.Chaincode]; ok {
+			definition.Package = pkg
+		}
+
+		result, err := deployer.Deploy(ctx, definition)
+		if err != nil {
+			return results, fmt.Errorf(`deploy chaincode=%s: %w`, step.Chaincode, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// String returns human readable plan
+func (p *Plan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "channel %s, V2_0 application capability enabled: %t\n", p.Channel, p.CapabilityEnabled)
+	for i, step := range p.Steps {
+		fmt.Fprintf(&b, "%d. %s chaincode %s version %s", i+1, step.Action, step.Chaincode, step.Definition.Version)
+		if step.Action == ActionDeploy {
+			fmt.Fprintf(&b, ", package label %s", step.Label)
+		}
+		b.WriteString("\n")
+		for _, warning := range step.Warnings {
+			fmt.Fprintf(&b, "   warning: %s\n", warning)
+		}
+	}
+	return b.String()
+}
+
+func (s *Step) warn(format string, args ...interface{}) {
+	s.Warnings = append(s.Warnings, fmt.Sprintf(format, args...))
+}
+
+func applicationV20Enabled(config *common.Config) bool {
+	application, ok := config.GetChannelGroup().GetGroups()[channelconfig.ApplicationGroupKey]
+	if !ok {
+		return false
+	}
+
+	value, ok := application.Values[channelconfig.CapabilitiesKey]
+	if !ok {
+		return false
+	}
+
+	enabled := &common.Capabilities{}
+	if err := proto.Unmarshal(value.Value, enabled); err != nil {
+		return false
+	}
+
+	_, ok = enabled.Capabilities[capabilities.ApplicationV2_0]
+	return ok
+}
@@ -0,0 +1,166 @@
+package migrate_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	lifecycleproto "github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policydsl"
+	lifecyclecc "github.com/hyperledger/fabric/core/chaincode/lifecycle"
+	lsccPkg "github.com/hyperledger/fabric/core/scc/lscc"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/deploy"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/migrate"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/system"
+)
+
+type (
+	// channel - lscc and _lifecycle state of channel
+	channel struct {
+		capabilities map[string]bool
+		lscc         map[string]*peer.ChaincodeData
+		collections  map[string]*peer.CollectionConfigPackage
+		lifecycle    []string
+	}
+)
+
+func (c *channel) CurrentIdentity() msp.SigningIdentity {
+	return nil
+}
+
+func (c *channel) Invoke(context.Context, string, string, [][]byte, msp.SigningIdentity, map[string][]byte, string) (*peer.Response, string, error) {
+	return nil, ``, errors.New(`not implemented`)
+}
+
+func (c *channel) Query(_ context.Context, _, chaincode string, args [][]byte, _ msp.SigningIdentity, _ map[string][]byte) (*peer.Response, error) {
+	fn := string(args[0])
+	switch {
+	case chaincode == system.CSCCName && fn == system.GetChannelConfig:
+		return response(&common.Config{ChannelGroup: &common.ConfigGroup{
+			Groups: map[string]*common.ConfigGroup{
+				channelconfig.ApplicationGroupKey: {Values: map[string]*common.ConfigValue{
+					channelconfig.CapabilitiesKey: {Value: marshal(channelconfig.CapabilitiesValue(c.capabilities).Value())},
+				}},
+			},
+		}})
+
+	case chaincode == system.LSCCName && fn == lsccPkg.GETCHAINCODES:
+		result := &peer.ChaincodeQueryResponse{}
+		for _, name := range []string{`cc1`, `cc2`, `cc3`} {
+			if data, ok := c.lscc[name]; ok {
+				result.Chaincodes = append(result.Chaincodes, &peer.ChaincodeInfo{Name: data.Name, Version: data.Version})
+			}
+		}
+		return response(result)
+
+	case chaincode == system.LSCCName && fn == lsccPkg.GETCCDATA:
+		return response(c.lscc[string(args[2])])
+
+	case chaincode == system.LSCCName && fn == lsccPkg.GETCOLLECTIONSCONFIG:
+		collections, ok := c.collections[string(args[1])]
+		if !ok {
+			return nil, fmt.Errorf(`collections config not defined for chaincode %s`, args[1])
+		}
+		return response(collections)
+
+	case chaincode == system.LifecycleName && fn == lifecyclecc.QueryChaincodeDefinitionsFuncName:
+		result := &lifecycleproto.QueryChaincodeDefinitionsResult{}
+		for _, name := range c.lifecycle {
+			result.ChaincodeDefinitions = append(result.ChaincodeDefinitions,
+				&lifecycleproto.QueryChaincodeDefinitionsResult_ChaincodeDefinition{Name: name, Sequence: 1})
+		}
+		return response(result)
+	}
+
+	return nil, fmt.Errorf(`unexpected query %s: %s`, chaincode, fn)
+}
+
+func marshal(msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func response(msg proto.Message) (*peer.Response, error) {
+	return &peer.Response{Status: 200, Payload: marshal(msg)}, nil
+}
+
+func TestPlanner_Plan(t *testing.T) {
+	policy := policydsl.SignedByAnyMember([]string{`Org1MSP`, `Org2MSP`})
+	collections := &peer.CollectionConfigPackage{Config: []*peer.CollectionConfig{{
+		Payload: &peer.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: &peer.StaticCollectionConfig{
+			Name:              `private`,
+			MemberOrgsPolicy:  &peer.CollectionPolicyConfig{Payload: &peer.CollectionPolicyConfig_SignaturePolicy{SignaturePolicy: policy}},
+			RequiredPeerCount: 1,
+			MaximumPeerCount:  2,
+		}},
+	}}}
+
+	ch := &channel{
+		capabilities: map[string]bool{`V1_4_2`: true},
+		lscc: map[string]*peer.ChaincodeData{
+			`cc1`: {Name: `cc1`, Version: `1.0`, Escc: `escc`, Vscc: `vscc`, Policy: policy},
+			`cc2`: {Name: `cc2`, Version: `0.1`, Escc: `escc`, Vscc: `custom`, Policy: policy,
+				InstantiationPolicy: policydsl.SignedByMspAdmin(`Org1MSP`)},
+			`cc3`: {Name: `cc3`, Version: `2.0`, Escc: `escc`, Vscc: `vscc`, Policy: policy},
+		},
+		collections: map[string]*peer.CollectionConfigPackage{`cc1`: collections},
+		lifecycle:   []string{`cc3`},
+	}
+
+	ctx := context.Background()
+	plan, err := migrate.NewPlanner(ch).Plan(ctx, `channel`)
+	if err != nil {
+		t.Fatalf("plan: %s", err)
+	}
+
+	if plan.CapabilityEnabled || len(plan.Steps) != 3 {
+		t.Fatalf("unexpected plan: %s", plan)
+	}
+
+	cc1 := plan.Steps[0]
+	validationParameter := marshal(&peer.ApplicationPolicy{Type: &peer.ApplicationPolicy_SignaturePolicy{SignaturePolicy: policy}})
+	if cc1.Action != migrate.ActionDeploy || cc1.Label != `cc1_1.0` || cc1.Definition.Sequence != 1 ||
+		string(cc1.Definition.ValidationParameter) != string(validationParameter) ||
+		!proto.Equal(cc1.Definition.Collections, collections) || len(cc1.Warnings) != 0 {
+		t.Fatalf("unexpected cc1 step: %+v", cc1)
+	}
+
+	if cc2 := plan.Steps[1]; cc2.Definition.Collections != nil || len(cc2.Warnings) != 2 {
+		t.Fatalf("unexpected cc2 step: %+v", cc2)
+	}
+
+	if cc3 := plan.Steps[2]; cc3.Action != migrate.ActionSkip {
+		t.Fatalf("cc3: expected action= %s, got= %s", migrate.ActionSkip, cc3.Action)
+	}
+
+	if pending := plan.Pending(); len(pending) != 2 {
+		t.Fatalf("expected 2 pending steps, got %d", len(pending))
+	}
+
+	deployer := deploy.New(nil)
+	if _, err = plan.Execute(ctx, deployer, nil); !errors.Is(err, migrate.ErrCapabilityNotEnabled) {
+		t.Fatalf("execute: expected= %s, got= %v", migrate.ErrCapabilityNotEnabled, err)
+	}
+
+	ch.capabilities[`V2_0`] = true
+	if plan, err = migrate.NewPlanner(ch).Plan(ctx, `channel`); err != nil {
+		t.Fatalf("plan: %s", err)
+	}
+	if !plan.CapabilityEnabled {
+		t.Fatal(`expected V2_0 capability enabled`)
+	}
+
+	if _, err = plan.Execute(ctx, deployer, map[string][]byte{`cc1`: []byte(`package`)}); !errors.Is(err, migrate.ErrPackageMissing) {
+		t.Fatalf("execute: expected= %s, got= %v", migrate.ErrPackageMissing, err)
+	}
+}
@@ -24,6 +24,9 @@ const (
 	GetChannels      string = "GetChannels"
 	GetConfigTree    string = `GetConfigTree`    // HLF Peer V1.x
 	GetChannelConfig string = "GetChannelConfig" // HLF Peer V2 +
+
+	JoinChainBySnapshot  string = "JoinChainBySnapshot"  // HLF Peer V2.3 +
+	JoinBySnapshotStatus string = "JoinBySnapshotStatus" // HLF Peer V2.3 +
 )
 
 type (
@@ -88,6 +91,22 @@ func (c *CSCCService) JoinChain(ctx context.Context, request *JoinChainRequest)
 	return &empty.Empty{}, nil
 }
 
+// JoinBySnapshot joins peer to channel from snapshot, snapshotDir is path to snapshot on peer file system.
+// Join is processed by peer asynchronously, progress can be checked with GetJoinBySnapshotStatus
+func (c *CSCCService) JoinBySnapshot(ctx context.Context, snapshotDir string) error {
+	_, err := c.Querier.Query(ctx, JoinChainBySnapshot, snapshotDir)
+	return err
+}
+
+// GetJoinBySnapshotStatus returns status of join by snapshot in progress
+func (c *CSCCService) GetJoinBySnapshotStatus(ctx context.Context) (*peer.JoinBySnapshotStatus, error) {
+	res, err := c.Querier.QueryStringsProto(ctx, []string{JoinBySnapshotStatus}, &peer.JoinBySnapshotStatus{})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*peer.JoinBySnapshotStatus), nil
+}
+
 func (c *CSCCService) GetConfigBlock(ctx context.Context, request *GetConfigBlockRequest) (*common.Block, error) {
 	res, err := c.Querier.QueryProto(ctx, []interface{}{GetConfigBlock, request.Channel}, &common.Block{})
 	if err != nil {
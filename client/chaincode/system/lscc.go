@@ -4,6 +4,7 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
+	"strings"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric-protos-go/peer"
@@ -13,6 +14,9 @@ import (
 	"github.com/vitiko/hlf-sdk-go/client/tx"
 )
 
+// collectionsConfigNotDefined - part of lscc error message if chaincode has no collections
+const collectionsConfigNotDefined = `collections config not defined`
+
 //go:embed lscc.swagger.json
 var LSCCServiceSwagger []byte
 
@@ -86,6 +90,23 @@ func (l *LSCCService) GetDeploymentSpec(ctx context.Context, getDeploymentSpec *
 	}
 	return res.(*peer.ChaincodeDeploymentSpec), nil
 }
+
+// GetCollectionsConfig returns collections of instantiated chaincode, empty package if collections are not defined
+func (l *LSCCService) GetCollectionsConfig(ctx context.Context, channel, chaincode string) (*peer.CollectionConfigPackage, error) {
+	res, err := tx.QueryStringsProto(ctx,
+		l.Invoker,
+		channel, LSCCName,
+		[]string{lsccPkg.GETCOLLECTIONSCONFIG, chaincode},
+		&peer.CollectionConfigPackage{})
+	if err != nil {
+		if strings.Contains(err.Error(), collectionsConfigNotDefined) {
+			return &peer.CollectionConfigPackage{}, nil
+		}
+		return nil, err
+	}
+	return res.(*peer.CollectionConfigPackage), nil
+}
+
 func (l *LSCCService) Install(ctx context.Context, spec *peer.ChaincodeDeploymentSpec) (*empty.Empty, error) {
 	_, err := tx.QueryProto(ctx,
 		l.Invoker,
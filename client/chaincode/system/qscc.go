@@ -55,6 +55,13 @@ func (q *QSCCService) GetBlockByNumber(ctx context.Context, request *GetBlockByN
 	return res.(*common.Block), nil
 }
 
+// BlockFetcher returns function for fetching channel blocks by number, i.e. for refetch missing blocks in subscription
+func (q *QSCCService) BlockFetcher(channelName string) func(ctx context.Context, blockNumber uint64) (*common.Block, error) {
+	return func(ctx context.Context, blockNumber uint64) (*common.Block, error) {
+		return q.GetBlockByNumber(ctx, &GetBlockByNumberRequest{ChannelName: channelName, BlockNumber: int64(blockNumber)})
+	}
+}
+
 func (q *QSCCService) GetBlockByHash(ctx context.Context, request *GetBlockByHashRequest) (*common.Block, error) {
 	res, err := q.Querier.QueryStringsProto(ctx,
 		[]string{qscccore.GetBlockByHash, request.ChannelName, string(request.BlockHash)},
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+	"google.golang.org/grpc"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+)
+
+//go:embed snapshot.swagger.json
+var SnapshotServiceSwagger []byte
+
+type (
+	// SnapshotService wraps peer Snapshot gRPC service, requests are signed with admin identity of peer org
+	SnapshotService struct {
+		UnimplementedSnapshotServiceServer
+
+		Client peer.SnapshotClient
+		Signer msp.SigningIdentity
+	}
+)
+
+func NewSnapshot(conn *grpc.ClientConn, signer msp.SigningIdentity) *SnapshotService {
+	return &SnapshotService{
+		Client: peer.NewSnapshotClient(conn),
+		Signer: signer,
+	}
+}
+
+func NewSnapshotFromPeer(p api.Peer, signer msp.SigningIdentity) *SnapshotService {
+	return NewSnapshot(p.Conn(), signer)
+}
+
+func (s *SnapshotService) ServiceDef() ServiceDef {
+	return NewServiceDef(
+		_SnapshotService_serviceDesc.ServiceName,
+		SnapshotServiceSwagger,
+		&_SnapshotService_serviceDesc,
+		s,
+		RegisterSnapshotServiceHandlerFromEndpoint,
+	)
+}
+
+func (s *SnapshotService) Generate(ctx context.Context, request *SnapshotRequest) (*empty.Empty, error) {
+	signed, err := s.signedRequest(request.ChannelId, request.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.Client.Generate(ctx, signed)
+}
+
+func (s *SnapshotService) Cancel(ctx context.Context, request *SnapshotRequest) (*empty.Empty, error) {
+	signed, err := s.signedRequest(request.ChannelId, request.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return s.Client.Cancel(ctx, signed)
+}
+
+func (s *SnapshotService) QueryPendings(ctx context.Context, request *SnapshotQuery) (*peer.QueryPendingSnapshotsResponse, error) {
+	signatureHeader, err := protoutil.NewSignatureHeader(s.Signer)
+	if err != nil {
+		return nil, fmt.Errorf(`signature header: %w`, err)
+	}
+
+	signed, err := s.signMessage(&peer.SnapshotQuery{
+		SignatureHeader: signatureHeader,
+		ChannelId:       request.ChannelId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Client.QueryPendings(ctx, signed)
+}
+
+func (s *SnapshotService) signedRequest(channel string, blockNumber uint64) (*peer.SignedSnapshotRequest, error) {
+	signatureHeader, err := protoutil.NewSignatureHeader(s.Signer)
+	if err != nil {
+		return nil, fmt.Errorf(`signature header: %w`, err)
+	}
+
+	return s.signMessage(&peer.SnapshotRequest{
+		SignatureHeader: signatureHeader,
+		ChannelId:       channel,
+		BlockNumber:     blockNumber,
+	})
+}
+
+func (s *SnapshotService) signMessage(msg proto.Message) (*peer.SignedSnapshotRequest, error) {
+	requestBytes, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal snapshot request: %w`, err)
+	}
+
+	signature, err := s.Signer.Sign(requestBytes)
+	if err != nil {
+		return nil, fmt.Errorf(`sign snapshot request: %w`, err)
+	}
+
+	return &peer.SignedSnapshotRequest{Request: requestBytes, Signature: signature}, nil
+}
@@ -0,0 +1,133 @@
+package system_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"google.golang.org/grpc"
+
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/system"
+	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
+	"github.com/vitiko/hlf-sdk-go/identity"
+)
+
+// snapshotClient records signed requests and returns pending snapshots
+type snapshotClient struct {
+	peer.SnapshotClient
+
+	generated []*peer.SignedSnapshotRequest
+	cancelled []*peer.SignedSnapshotRequest
+	queried   []*peer.SignedSnapshotRequest
+	pendings  []uint64
+}
+
+func (c *snapshotClient) Generate(_ context.Context, in *peer.SignedSnapshotRequest, _ ...grpc.CallOption) (*empty.Empty, error) {
+	c.generated = append(c.generated, in)
+	return &empty.Empty{}, nil
+}
+
+func (c *snapshotClient) Cancel(_ context.Context, in *peer.SignedSnapshotRequest, _ ...grpc.CallOption) (*empty.Empty, error) {
+	c.cancelled = append(c.cancelled, in)
+	return &empty.Empty{}, nil
+}
+
+func (c *snapshotClient) QueryPendings(_ context.Context, in *peer.SignedSnapshotRequest, _ ...grpc.CallOption) (
+	*peer.QueryPendingSnapshotsResponse, error) {
+	c.queried = append(c.queried, in)
+	return &peer.QueryPendingSnapshotsResponse{BlockNumbers: c.pendings}, nil
+}
+
+func snapshotSigner(t *testing.T) msp.SigningIdentity {
+	id, err := identity.SignerFromMSPPath(`Org1MSP`, `../../../identity/testdata/Org1MSPAdmin`)
+	if err != nil {
+		t.Fatalf("load signer: %s", err)
+	}
+
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatalf("crypto suite: %s", err)
+	}
+
+	return id.GetSigningIdentity(cs)
+}
+
+// checkSigned verifies signature of request and signature header of unmarshalled request
+func checkSigned(t *testing.T, signer msp.SigningIdentity, signed *peer.SignedSnapshotRequest, request interface {
+	proto.Message
+	GetSignatureHeader() *common.SignatureHeader
+}) {
+	if err := signer.Verify(signed.Request, signed.Signature); err != nil {
+		t.Fatalf("verify signature: %s", err)
+	}
+
+	if err := proto.Unmarshal(signed.Request, request); err != nil {
+		t.Fatalf("unmarshal request: %s", err)
+	}
+
+	header := request.GetSignatureHeader()
+	creator, err := signer.Serialize()
+	if err != nil {
+		t.Fatalf("serialize signer: %s", err)
+	}
+	if !bytes.Equal(header.Creator, creator) {
+		t.Fatal(`signature header creator is not signer`)
+	}
+	if len(header.Nonce) == 0 {
+		t.Fatal(`signature header nonce is empty`)
+	}
+}
+
+func TestSnapshotService(t *testing.T) {
+	ctx := context.Background()
+	signer := snapshotSigner(t)
+	client := &snapshotClient{pendings: []uint64{10, 20}}
+	service := &system.SnapshotService{Client: client, Signer: signer}
+
+	if _, err := service.Generate(ctx, &system.SnapshotRequest{ChannelId: `channel`, BlockNumber: 10}); err != nil {
+		t.Fatalf("generate: %s", err)
+	}
+	if _, err := service.Cancel(ctx, &system.SnapshotRequest{ChannelId: `channel`, BlockNumber: 20}); err != nil {
+		t.Fatalf("cancel: %s", err)
+	}
+
+	res, err := service.QueryPendings(ctx, &system.SnapshotQuery{ChannelId: `channel`})
+	if err != nil {
+		t.Fatalf("query pendings: %s", err)
+	}
+	if len(res.BlockNumbers) != 2 || res.BlockNumbers[0] != 10 || res.BlockNumbers[1] != 20 {
+		t.Fatalf("pendings: expected= [10 20], got= %v", res.BlockNumbers)
+	}
+
+	if len(client.generated) != 1 || len(client.cancelled) != 1 || len(client.queried) != 1 {
+		t.Fatalf("requests: expected= 1 of each, got= %d generate, %d cancel, %d query",
+			len(client.generated), len(client.cancelled), len(client.queried))
+	}
+
+	for _, s := range []struct {
+		signed      *peer.SignedSnapshotRequest
+		blockNumber uint64
+	}{
+		{signed: client.generated[0], blockNumber: 10},
+		{signed: client.cancelled[0], blockNumber: 20},
+	} {
+		request := &peer.SnapshotRequest{}
+		checkSigned(t, signer, s.signed, request)
+
+		if request.ChannelId != `channel` || request.BlockNumber != s.blockNumber {
+			t.Fatalf("snapshot request: expected= channel %d, got= %s %d", s.blockNumber, request.ChannelId, request.BlockNumber)
+		}
+	}
+
+	query := &peer.SnapshotQuery{}
+	checkSigned(t, signer, client.queried[0], query)
+	if query.ChannelId != `channel` {
+		t.Fatalf("snapshot query: expected= channel, got= %s", query.ChannelId)
+	}
+}
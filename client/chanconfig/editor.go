@@ -0,0 +1,540 @@
+// Package chanconfig modifies channel config and computes config update ready for signing
+package chanconfig
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	ordererproto "github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/msp"
+
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+const (
+	// EndorsementPolicyKey - org and application policy used by _lifecycle for chaincode endorsement by default
+	EndorsementPolicyKey = `Endorsement`
+
+	// ConsensusTypeEtcdRaft - consensus type with consenters in metadata
+	ConsensusTypeEtcdRaft = `etcdraft`
+)
+
+var (
+	ErrGroupNotFound          = errors.New(`config group not found`)
+	ErrGroupAlreadyExists     = errors.New(`config group already exists`)
+	ErrValueNotFound          = errors.New(`config value not found`)
+	ErrPolicyNotFound         = errors.New(`config policy not found`)
+	ErrConsensusTypeNotRaft   = errors.New(`consensus type is not etcdraft`)
+	ErrConsenterAlreadyExists = errors.New(`consenter already exists`)
+	ErrConsenterNotFound      = errors.New(`consenter not found`)
+	ErrEndpointNotFound       = errors.New(`orderer endpoint not found`)
+)
+
+type (
+	// Editor applies modifications to copy of channel config. All methods return editor itself,
+	// first error occurred is returned by Build
+	Editor struct {
+		channelID string
+		original  *common.Config
+		updated   *common.Config
+		err       error
+	}
+)
+
+// NewEditor creates editor for channel config, e.g. received with CSCCService.GetChannelConfig.
+// Original config is not modified
+func NewEditor(channelID string, config *common.Config) *Editor {
+	e := &Editor{
+		channelID: channelID,
+		original:  config,
+	}
+
+	if config.GetChannelGroup() == nil {
+		e.err = util.ErrConfigChannelGroupMissing
+		return e
+	}
+
+	e.updated = proto.Clone(config).(*common.Config)
+	return e
+}
+
+// Build returns update transforming original config to modified one
+func (e *Editor) Build() (*common.ConfigUpdate, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return util.ComputeConfigUpdate(e.channelID, e.original, e.updated)
+}
+
+// Config returns modified config
+func (e *Editor) Config() (*common.Config, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+
+	return e.updated, nil
+}
+
+// AddApplicationOrgFromMSPDir adds application org with verifying MSP config loaded from MSP directory
+// (cacerts, intermediatecerts, tlscacerts, config.yaml etc.)
+func (e *Editor) AddApplicationOrgFromMSPDir(mspID, mspDir string) *Editor {
+	return e.apply(func() error {
+		mspConfig, err := msp.GetVerifyingMspConfig(mspDir, mspID, msp.ProviderTypeToString(msp.FABRIC))
+		if err != nil {
+			return fmt.Errorf(`load msp config from dir=%s: %w`, mspDir, err)
+		}
+
+		return e.addApplicationOrg(mspID, mspConfig)
+	})
+}
+
+// AddApplicationOrg adds application org group named as MSP identifier with default policies:
+// Readers, Writers and Endorsement are signed by org member, Admins - by org admin
+func (e *Editor) AddApplicationOrg(mspID string, mspConfig *mspproto.MSPConfig) *Editor {
+	return e.apply(func() error {
+		return e.addApplicationOrg(mspID, mspConfig)
+	})
+}
+
+// RemoveApplicationOrg removes application org group
+func (e *Editor) RemoveApplicationOrg(org string) *Editor {
+	return e.apply(func() error {
+		application, err := e.group(channelconfig.ApplicationGroupKey)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := application.Groups[org]; !ok {
+			return fmt.Errorf(`application org=%s: %w`, org, ErrGroupNotFound)
+		}
+
+		delete(application.Groups, org)
+		return nil
+	})
+}
+
+// SetAnchorPeers replaces anchor peers of application org, anchor peers are removed if no peers provided
+func (e *Editor) SetAnchorPeers(org string, anchorPeers ...*peer.AnchorPeer) *Editor {
+	return e.apply(func() error {
+		orgGroup, err := e.group(channelconfig.ApplicationGroupKey, org)
+		if err != nil {
+			return err
+		}
+
+		if len(anchorPeers) == 0 {
+			delete(orgGroup.Values, channelconfig.AnchorPeersKey)
+			return nil
+		}
+
+		return setValue(orgGroup, channelconfig.AnchorPeersValue(anchorPeers))
+	})
+}
+
+// SetBatchSize sets orderer batch size
+func (e *Editor) SetBatchSize(maxMessageCount, absoluteMaxBytes, preferredMaxBytes uint32) *Editor {
+	return e.apply(func() error {
+		ordererGroup, err := e.group(channelconfig.OrdererGroupKey)
+		if err != nil {
+			return err
+		}
+
+		return setValue(ordererGroup, channelconfig.BatchSizeValue(maxMessageCount, absoluteMaxBytes, preferredMaxBytes))
+	})
+}
+
+// SetBatchTimeout sets orderer batch timeout
+func (e *Editor) SetBatchTimeout(timeout time.Duration) *Editor {
+	return e.apply(func() error {
+		ordererGroup, err := e.group(channelconfig.OrdererGroupKey)
+		if err != nil {
+			return err
+		}
+
+		return setValue(ordererGroup, channelconfig.BatchTimeoutValue(timeout.String()))
+	})
+}
+
+// AddConsenter adds Raft consenter to etcdraft consensus metadata
+func (e *Editor) AddConsenter(consenter *etcdraft.Consenter) *Editor {
+	return e.modifyRaftMetadata(func(metadata *etcdraft.ConfigMetadata) error {
+		for _, c := range metadata.Consenters {
+			if c.Host == consenter.Host && c.Port == consenter.Port {
+				return fmt.Errorf(`consenter=%s:%d: %w`, consenter.Host, consenter.Port, ErrConsenterAlreadyExists)
+			}
+		}
+
+		metadata.Consenters = append(metadata.Consenters, consenter)
+		return nil
+	})
+}
+
+// RemoveConsenter removes Raft consenter with host and port from etcdraft consensus metadata
+func (e *Editor) RemoveConsenter(host string, port uint32) *Editor {
+	return e.modifyRaftMetadata(func(metadata *etcdraft.ConfigMetadata) error {
+		for i, c := range metadata.Consenters {
+			if c.Host == host && c.Port == port {
+				metadata.Consenters = append(metadata.Consenters[:i], metadata.Consenters[i+1:]...)
+				return nil
+			}
+		}
+
+		return fmt.Errorf(`consenter=%s:%d: %w`, host, port, ErrConsenterNotFound)
+	})
+}
+
+// AddOrdererEndpoint adds endpoint (host:port) to orderer org endpoints
+func (e *Editor) AddOrdererEndpoint(org, endpoint string) *Editor {
+	return e.modifyOrdererEndpoints(org, func(endpoints []string) ([]string, bool, error) {
+		for _, existing := range endpoints {
+			if existing == endpoint {
+				return endpoints, false, nil
+			}
+		}
+		return append(endpoints, endpoint), true, nil
+	})
+}
+
+// RemoveOrdererEndpoint removes endpoint (host:port) from orderer org endpoints,
+// endpoints value is removed with the last endpoint
+func (e *Editor) RemoveOrdererEndpoint(org, endpoint string) *Editor {
+	return e.modifyOrdererEndpoints(org, func(endpoints []string) ([]string, bool, error) {
+		for i, existing := range endpoints {
+			if existing == endpoint {
+				return append(endpoints[:i], endpoints[i+1:]...), true, nil
+			}
+		}
+
+		return nil, false, fmt.Errorf(`orderer org=%s endpoint=%s: %w`, org, endpoint, ErrEndpointNotFound)
+	})
+}
+
+// SetACL sets policy reference (e.g. /Channel/Application/Writers) for resource (e.g. peer/Propose)
+func (e *Editor) SetACL(resource, policyRef string) *Editor {
+	return e.modifyACLs(func(acls map[string]string) {
+		acls[resource] = policyRef
+	})
+}
+
+// RemoveACL removes resource from application ACLs, default policy will be used for resource
+func (e *Editor) RemoveACL(resource string) *Editor {
+	return e.modifyACLs(func(acls map[string]string) {
+		delete(acls, resource)
+	})
+}
+
+// SetPolicy adds or replaces policy of group, group is specified by path from channel group,
+// e.g. (Application, Org1MSP). Empty path means channel group
+func (e *Editor) SetPolicy(name string, policy *common.Policy, groupPath ...string) *Editor {
+	return e.apply(func() error {
+		group, err := e.group(groupPath...)
+		if err != nil {
+			return err
+		}
+
+		if group.Policies == nil {
+			group.Policies = make(map[string]*common.ConfigPolicy)
+		}
+
+		configPolicy, ok := group.Policies[name]
+		if !ok {
+			group.Policies[name] = &common.ConfigPolicy{ModPolicy: channelconfig.AdminsPolicyKey, Policy: policy}
+			return nil
+		}
+
+		configPolicy.Policy = policy
+		return nil
+	})
+}
+
+// RemovePolicy removes policy from group, group is specified by path from channel group
+func (e *Editor) RemovePolicy(name string, groupPath ...string) *Editor {
+	return e.apply(func() error {
+		group, err := e.group(groupPath...)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := group.Policies[name]; !ok {
+			return fmt.Errorf(`policy=%s: %w`, name, ErrPolicyNotFound)
+		}
+
+		delete(group.Policies, name)
+		return nil
+	})
+}
+
+// AddCapability enables capability (e.g. V2_0) in group, group is specified by path from channel group:
+// empty path for channel capabilities, Application or Orderer for application and orderer capabilities
+func (e *Editor) AddCapability(capability string, groupPath ...string) *Editor {
+	return e.modifyCapabilities(groupPath, func(capabilities map[string]bool) {
+		capabilities[capability] = true
+	})
+}
+
+// RemoveCapability disables capability in group, group is specified by path from channel group
+func (e *Editor) RemoveCapability(capability string, groupPath ...string) *Editor {
+	return e.modifyCapabilities(groupPath, func(capabilities map[string]bool) {
+		delete(capabilities, capability)
+	})
+}
+
+func (e *Editor) apply(modify func() error) *Editor {
+	if e.err != nil {
+		return e
+	}
+
+	e.err = modify()
+	return e
+}
+
+// group returns group of updated config by path from channel group
+func (e *Editor) group(path ...string) (*common.ConfigGroup, error) {
+	group := e.updated.ChannelGroup
+	for i, name := range path {
+		next, ok := group.Groups[name]
+		if !ok {
+			return nil, fmt.Errorf(`group=%v: %w`, path[:i+1], ErrGroupNotFound)
+		}
+		group = next
+	}
+
+	return group, nil
+}
+
+func (e *Editor) addApplicationOrg(mspID string, mspConfig *mspproto.MSPConfig) error {
+	application, err := e.group(channelconfig.ApplicationGroupKey)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := application.Groups[mspID]; ok {
+		return fmt.Errorf(`application org=%s: %w`, mspID, ErrGroupAlreadyExists)
+	}
+
+	orgGroup, err := NewOrgGroup(mspID, mspConfig)
+	if err != nil {
+		return err
+	}
+
+	if application.Groups == nil {
+		application.Groups = make(map[string]*common.ConfigGroup)
+	}
+	application.Groups[mspID] = orgGroup
+
+	return nil
+}
+
+func (e *Editor) modifyRaftMetadata(modify func(*etcdraft.ConfigMetadata) error) *Editor {
+	return e.apply(func() error {
+		ordererGroup, err := e.group(channelconfig.OrdererGroupKey)
+		if err != nil {
+			return err
+		}
+
+		consensusTypeValue, ok := ordererGroup.Values[channelconfig.ConsensusTypeKey]
+		if !ok {
+			return fmt.Errorf(`%s: %w`, channelconfig.ConsensusTypeKey, ErrValueNotFound)
+		}
+
+		consensusType := &ordererproto.ConsensusType{}
+		if err = proto.Unmarshal(consensusTypeValue.Value, consensusType); err != nil {
+			return fmt.Errorf(`unmarshal consensus type: %w`, err)
+		}
+
+		if consensusType.Type != ConsensusTypeEtcdRaft {
+			return fmt.Errorf(`consensus type=%s: %w`, consensusType.Type, ErrConsensusTypeNotRaft)
+		}
+
+		metadata := &etcdraft.ConfigMetadata{}
+		if err = proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+			return fmt.Errorf(`unmarshal etcdraft metadata: %w`, err)
+		}
+
+		if err = modify(metadata); err != nil {
+			return err
+		}
+
+		if consensusType.Metadata, err = proto.Marshal(metadata); err != nil {
+			return fmt.Errorf(`marshal etcdraft metadata: %w`, err)
+		}
+
+		if consensusTypeValue.Value, err = proto.Marshal(consensusType); err != nil {
+			return fmt.Errorf(`marshal consensus type: %w`, err)
+		}
+
+		return nil
+	})
+}
+
+// modifyOrdererEndpoints writes endpoints returned by modify if they are changed
+func (e *Editor) modifyOrdererEndpoints(org string, modify func([]string) ([]string, bool, error)) *Editor {
+	return e.apply(func() error {
+		orgGroup, err := e.group(channelconfig.OrdererGroupKey, org)
+		if err != nil {
+			return err
+		}
+
+		endpoints := &common.OrdererAddresses{}
+		if value, ok := orgGroup.Values[channelconfig.EndpointsKey]; ok {
+			if err = proto.Unmarshal(value.Value, endpoints); err != nil {
+				return fmt.Errorf(`unmarshal orderer org=%s endpoints: %w`, org, err)
+			}
+		}
+
+		modified, changed, err := modify(endpoints.Addresses)
+		switch {
+		case err != nil:
+			return err
+		case !changed:
+			return nil
+		case len(modified) == 0:
+			delete(orgGroup.Values, channelconfig.EndpointsKey)
+			return nil
+		}
+
+		return setValue(orgGroup, channelconfig.EndpointsValue(modified))
+	})
+}
+
+func (e *Editor) modifyACLs(modify func(map[string]string)) *Editor {
+	return e.apply(func() error {
+		application, err := e.group(channelconfig.ApplicationGroupKey)
+		if err != nil {
+			return err
+		}
+
+		acls := &peer.ACLs{}
+		if value, ok := application.Values[channelconfig.ACLsKey]; ok {
+			if err = proto.Unmarshal(value.Value, acls); err != nil {
+				return fmt.Errorf(`unmarshal ACLs: %w`, err)
+			}
+		}
+
+		policyRefs := make(map[string]string)
+		for resource, acl := range acls.Acls {
+			policyRefs[resource] = acl.GetPolicyRef()
+		}
+
+		modify(policyRefs)
+
+		// value is not rewritten, so it is not included to write set of config update
+		if len(policyRefs) == len(acls.Acls) {
+			unchanged := true
+			for resource, acl := range acls.Acls {
+				if ref, ok := policyRefs[resource]; !ok || ref != acl.GetPolicyRef() {
+					unchanged = false
+					break
+				}
+			}
+			if unchanged {
+				return nil
+			}
+		}
+
+		return setValue(application, channelconfig.ACLValues(policyRefs))
+	})
+}
+
+func (e *Editor) modifyCapabilities(groupPath []string, modify func(map[string]bool)) *Editor {
+	return e.apply(func() error {
+		group, err := e.group(groupPath...)
+		if err != nil {
+			return err
+		}
+
+		capabilities := &common.Capabilities{}
+		if value, ok := group.Values[channelconfig.CapabilitiesKey]; ok {
+			if err = proto.Unmarshal(value.Value, capabilities); err != nil {
+				return fmt.Errorf(`unmarshal capabilities: %w`, err)
+			}
+		}
+
+		enabled := make(map[string]bool)
+		for capability := range capabilities.Capabilities {
+			enabled[capability] = true
+		}
+
+		modify(enabled)
+
+		// value is not rewritten, so it is not included to write set of config update
+		if len(enabled) == len(capabilities.Capabilities) {
+			unchanged := true
+			for capability := range capabilities.Capabilities {
+				if !enabled[capability] {
+					unchanged = false
+					break
+				}
+			}
+			if unchanged {
+				return nil
+			}
+		}
+
+		return setValue(group, channelconfig.CapabilitiesValue(enabled))
+	})
+}
+
+// NewOrgGroup creates org config group with MSP value and default signature policies
+func NewOrgGroup(mspID string, mspConfig *mspproto.MSPConfig) (*common.ConfigGroup, error) {
+	orgGroup := &common.ConfigGroup{
+		ModPolicy: channelconfig.AdminsPolicyKey,
+		Groups:    make(map[string]*common.ConfigGroup),
+		Values:    make(map[string]*common.ConfigValue),
+		Policies:  make(map[string]*common.ConfigPolicy),
+	}
+
+	if err := setValue(orgGroup, channelconfig.MSPValue(mspConfig)); err != nil {
+		return nil, err
+	}
+
+	policies := map[string]*common.SignaturePolicyEnvelope{
+		channelconfig.ReadersPolicyKey: policydsl.SignedByMspMember(mspID),
+		channelconfig.WritersPolicyKey: policydsl.SignedByMspMember(mspID),
+		channelconfig.AdminsPolicyKey:  policydsl.SignedByMspAdmin(mspID),
+		EndorsementPolicyKey:           policydsl.SignedByMspMember(mspID),
+	}
+
+	for name, envelope := range policies {
+		policyBytes, err := proto.Marshal(envelope)
+		if err != nil {
+			return nil, fmt.Errorf(`marshal policy=%s: %w`, name, err)
+		}
+
+		orgGroup.Policies[name] = &common.ConfigPolicy{
+			ModPolicy: channelconfig.AdminsPolicyKey,
+			Policy:    &common.Policy{Type: int32(common.Policy_SIGNATURE), Value: policyBytes},
+		}
+	}
+
+	return orgGroup, nil
+}
+
+// setValue marshals standard value to group, mod policy of existing value is retained
+func setValue(group *common.ConfigGroup, value *channelconfig.StandardConfigValue) error {
+	valueBytes, err := proto.Marshal(value.Value())
+	if err != nil {
+		return fmt.Errorf(`marshal value=%s: %w`, value.Key(), err)
+	}
+
+	if group.Values == nil {
+		group.Values = make(map[string]*common.ConfigValue)
+	}
+
+	configValue, ok := group.Values[value.Key()]
+	if !ok {
+		group.Values[value.Key()] = &common.ConfigValue{ModPolicy: channelconfig.AdminsPolicyKey, Value: valueBytes}
+		return nil
+	}
+
+	configValue.Value = valueBytes
+	return nil
+}
@@ -0,0 +1,228 @@
+package chanconfig_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+
+	"github.com/vitiko/hlf-sdk-go/client/chanconfig"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+const (
+	channelID = `channel`
+	mspDir    = `../../identity/testdata/Org1MSPPeer`
+)
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func raftConfig(t *testing.T) *common.Config {
+	metadata := &etcdraft.ConfigMetadata{Consenters: []*etcdraft.Consenter{
+		{Host: `orderer1.example.com`, Port: 7050},
+	}}
+
+	return &common.Config{
+		ChannelGroup: &common.ConfigGroup{
+			ModPolicy: `Admins`,
+			Groups: map[string]*common.ConfigGroup{
+				channelconfig.ApplicationGroupKey: {
+					Version:   1,
+					ModPolicy: `Admins`,
+					Groups:    map[string]*common.ConfigGroup{},
+				},
+				channelconfig.OrdererGroupKey: {
+					Version:   2,
+					ModPolicy: `Admins`,
+					Groups: map[string]*common.ConfigGroup{
+						`OrdererOrg`: {
+							ModPolicy: `Admins`,
+							Values: map[string]*common.ConfigValue{
+								channelconfig.EndpointsKey: {ModPolicy: `Admins`,
+									Value: marshal(t, &common.OrdererAddresses{Addresses: []string{`orderer1.example.com:7050`}})},
+							},
+						},
+					},
+					Values: map[string]*common.ConfigValue{
+						channelconfig.ConsensusTypeKey: {ModPolicy: `Admins`,
+							Value: marshal(t, &orderer.ConsensusType{Type: `etcdraft`, Metadata: marshal(t, metadata)})},
+						channelconfig.BatchTimeoutKey: {ModPolicy: `Admins`,
+							Value: marshal(t, &orderer.BatchTimeout{Timeout: `2s`})},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEditor_Build(t *testing.T) {
+	original := raftConfig(t)
+
+	update, err := chanconfig.NewEditor(channelID, original).
+		AddApplicationOrgFromMSPDir(`Org1MSP`, mspDir).
+		SetAnchorPeers(`Org1MSP`, &peer.AnchorPeer{Host: `peer0.org1.example.com`, Port: 7051}).
+		SetBatchTimeout(500*time.Millisecond).
+		AddConsenter(&etcdraft.Consenter{Host: `orderer2.example.com`, Port: 7050}).
+		AddOrdererEndpoint(`OrdererOrg`, `orderer2.example.com:7050`).
+		SetACL(`peer/Propose`, `/Channel/Application/Writers`).
+		AddCapability(`V2_0`, channelconfig.ApplicationGroupKey).
+		Build()
+	if err != nil {
+		t.Fatalf("build: %s", err)
+	}
+
+	if update.ChannelId != channelID {
+		t.Fatalf("channel id: expected= %s, got= %s", channelID, update.ChannelId)
+	}
+
+	if !proto.Equal(original, raftConfig(t)) {
+		t.Fatal(`original config modified`)
+	}
+
+	application := update.WriteSet.Groups[channelconfig.ApplicationGroupKey]
+	// org added - application group version incremented
+	if application.GetVersion() != 2 {
+		t.Fatalf("application group version: expected= 2, got= %d", application.GetVersion())
+	}
+
+	org := application.Groups[`Org1MSP`]
+	for _, name := range []string{`Readers`, `Writers`, `Admins`, chanconfig.EndorsementPolicyKey} {
+		if _, ok := org.GetPolicies()[name]; !ok {
+			t.Fatalf("org policy=%s not found", name)
+		}
+	}
+
+	msp, err := hlfproto.ParseMSP(org, `Org1MSP`)
+	if err != nil {
+		t.Fatalf("parse org msp: %s", err)
+	}
+	if msp.Config.Name != `Org1MSP` || len(msp.Config.RootCerts) == 0 {
+		t.Fatalf("unexpected org msp config: %v", msp.Config)
+	}
+
+	anchorPeers, err := hlfproto.ParseAnchorPeers(org)
+	if err != nil || len(anchorPeers) != 1 || anchorPeers[0].Host != `peer0.org1.example.com` {
+		t.Fatalf("unexpected anchor peers: %v, err= %v", anchorPeers, err)
+	}
+
+	ordererGroup := update.WriteSet.Groups[channelconfig.OrdererGroupKey]
+	// only values are modified - orderer group version is the same
+	if ordererGroup.GetVersion() != 2 {
+		t.Fatalf("orderer group version: expected= 2, got= %d", ordererGroup.GetVersion())
+	}
+
+	batchTimeout := &orderer.BatchTimeout{}
+	if err = proto.Unmarshal(ordererGroup.Values[channelconfig.BatchTimeoutKey].GetValue(), batchTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if batchTimeout.Timeout != `500ms` || ordererGroup.Values[channelconfig.BatchTimeoutKey].Version != 1 {
+		t.Fatalf("unexpected batch timeout: %v", ordererGroup.Values[channelconfig.BatchTimeoutKey])
+	}
+
+	consensusType := &orderer.ConsensusType{}
+	if err = proto.Unmarshal(ordererGroup.Values[channelconfig.ConsensusTypeKey].GetValue(), consensusType); err != nil {
+		t.Fatal(err)
+	}
+	metadata := &etcdraft.ConfigMetadata{}
+	if err = proto.Unmarshal(consensusType.Metadata, metadata); err != nil {
+		t.Fatal(err)
+	}
+	if len(metadata.Consenters) != 2 || metadata.Consenters[1].Host != `orderer2.example.com` {
+		t.Fatalf("unexpected consenters: %v", metadata.Consenters)
+	}
+
+	endpoints, err := hlfproto.ParseOrdererEndpoints(
+		ordererGroup.Groups[`OrdererOrg`].Values[channelconfig.EndpointsKey].GetValue())
+	if err != nil || len(endpoints) != 2 {
+		t.Fatalf("unexpected orderer endpoints: %v, err= %v", endpoints, err)
+	}
+}
+
+func TestEditor_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*chanconfig.Editor) *chanconfig.Editor
+		err    error
+	}{
+		{
+			name: `unknown org`,
+			modify: func(e *chanconfig.Editor) *chanconfig.Editor {
+				return e.RemoveApplicationOrg(`Org2MSP`)
+			},
+			err: chanconfig.ErrGroupNotFound,
+		},
+		{
+			name: `first error retained`,
+			modify: func(e *chanconfig.Editor) *chanconfig.Editor {
+				return e.RemoveConsenter(`orderer3.example.com`, 7050).
+					SetAnchorPeers(`Org2MSP`, &peer.AnchorPeer{Host: `peer0.org2.example.com`, Port: 7051})
+			},
+			err: chanconfig.ErrConsenterNotFound,
+		},
+		{
+			name: `duplicated consenter`,
+			modify: func(e *chanconfig.Editor) *chanconfig.Editor {
+				return e.AddConsenter(&etcdraft.Consenter{Host: `orderer1.example.com`, Port: 7050})
+			},
+			err: chanconfig.ErrConsenterAlreadyExists,
+		},
+		{
+			name: `no modifications`,
+			modify: func(e *chanconfig.Editor) *chanconfig.Editor {
+				return e.AddOrdererEndpoint(`OrdererOrg`, `orderer1.example.com:7050`)
+			},
+			err: util.ErrConfigNoDifferences,
+		},
+		{
+			name: `missing orderer endpoint`,
+			modify: func(e *chanconfig.Editor) *chanconfig.Editor {
+				return e.RemoveOrdererEndpoint(`OrdererOrg`, `orderer2.example.com:7050`)
+			},
+			err: chanconfig.ErrEndpointNotFound,
+		},
+		{
+			name: `unchanged ACLs and capabilities`,
+			modify: func(e *chanconfig.Editor) *chanconfig.Editor {
+				return e.RemoveACL(`peer/Propose`).
+					RemoveCapability(`V2_0`, channelconfig.ApplicationGroupKey)
+			},
+			err: util.ErrConfigNoDifferences,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.modify(chanconfig.NewEditor(channelID, raftConfig(t))).Build()
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error: expected= %s, got= %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestEditor_RemoveLastOrdererEndpoint(t *testing.T) {
+	config, err := chanconfig.NewEditor(channelID, raftConfig(t)).
+		RemoveOrdererEndpoint(`OrdererOrg`, `orderer1.example.com:7050`).
+		Config()
+	if err != nil {
+		t.Fatalf("remove endpoint: %s", err)
+	}
+
+	org := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups[`OrdererOrg`]
+	if _, ok := org.Values[channelconfig.EndpointsKey]; ok {
+		t.Fatal(`endpoints value is not removed with the last endpoint`)
+	}
+}
@@ -0,0 +1,464 @@
+package chanconfig
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/genesis"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/identity"
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+const (
+	// LifecycleEndorsementPolicyKey - application policy for approving chaincode definitions
+	LifecycleEndorsementPolicyKey = `LifecycleEndorsement`
+	// BlockValidationPolicyKey - orderer policy for block signatures
+	BlockValidationPolicyKey = `BlockValidation`
+
+	ConsensusTypeSolo = `solo`
+)
+
+// Defaults used by configtxgen sample profiles
+var (
+	DefaultBatchTimeout = 2 * time.Second
+	DefaultBatchSize    = BatchSize{
+		MaxMessageCount:   500,
+		AbsoluteMaxBytes:  10 * 1024 * 1024,
+		PreferredMaxBytes: 2 * 1024 * 1024,
+	}
+	DefaultEtcdRaftOptions = &etcdraft.Options{
+		TickInterval:         `500ms`,
+		ElectionTick:         10,
+		HeartbeatTick:        1,
+		MaxInflightBlocks:    5,
+		SnapshotIntervalSize: 16 * 1024 * 1024,
+	}
+)
+
+var (
+	ErrProfileApplicationMissing = errors.New(`profile application section is missing`)
+	ErrProfileConsortiumMissing  = errors.New(`profile consortium is missing`)
+	ErrOrgMSPMissing             = errors.New(`org MSP is missing`)
+	ErrOrgMSPIDMissing           = errors.New(`org MSP identifier is missing`)
+	ErrUnknownOrdererType        = errors.New(`unknown orderer type`)
+)
+
+type (
+	// Profile - declarative channel configuration, as profile in configtx.yaml.
+	// Policies not set in profile are set to configtxgen sample defaults, policies set in profile override defaults with the same name
+	Profile struct {
+		// Consortium is required for channel creation transaction to orderer with system channel
+		Consortium   string
+		Capabilities []string
+		Policies     map[string]*common.Policy
+		Application  *ApplicationProfile
+		Orderer      *OrdererProfile
+	}
+
+	ApplicationProfile struct {
+		Orgs         []*OrgProfile
+		Capabilities []string
+		Policies     map[string]*common.Policy
+		ACLs         map[string]string
+	}
+
+	OrdererProfile struct {
+		// OrdererType - etcdraft (default) or solo
+		OrdererType string
+		// Addresses - global orderer addresses, endpoints in orderer orgs should be used instead
+		Addresses    []string
+		BatchTimeout time.Duration
+		BatchSize    BatchSize
+		MaxChannels  uint64
+		Consenters   []*etcdraft.Consenter
+		// EtcdRaftOptions - DefaultEtcdRaftOptions are used if not set
+		EtcdRaftOptions *etcdraft.Options
+		Orgs            []*OrgProfile
+		Capabilities    []string
+		Policies        map[string]*common.Policy
+	}
+
+	BatchSize struct {
+		MaxMessageCount   uint32
+		AbsoluteMaxBytes  uint32
+		PreferredMaxBytes uint32
+	}
+
+	// OrgProfile - org with MSP from identity.MSP or loaded from MSP directory
+	OrgProfile struct {
+		// Name of org group, MSP identifier is used if empty
+		Name string
+		// MSPID - MSP identifier, identifier from MSP is used if empty
+		MSPID string
+		// MSP - msp config, e.g. from identity.MSPFromPath. If not set, verifying MSP config is loaded from MSPDir
+		MSP    identity.MSP
+		MSPDir string
+		// AnchorPeers for application org
+		AnchorPeers []*peer.AnchorPeer
+		// OrdererEndpoints for orderer org
+		OrdererEndpoints []string
+		// Policies override default org policies with the same name
+		Policies map[string]*common.Policy
+	}
+)
+
+// NewConfig creates channel config from profile
+func NewConfig(profile *Profile) (*common.Config, error) {
+	channelGroup, err := NewChannelGroup(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.Config{ChannelGroup: channelGroup}, nil
+}
+
+// NewGenesisBlock creates genesis block of channel, block can be used to join orderers
+// with channel participation API and peers with CSCC JoinChain
+func NewGenesisBlock(channelID string, profile *Profile) (*common.Block, error) {
+	channelGroup, err := NewChannelGroup(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	return genesis.NewFactoryImpl(channelGroup).Block(channelID), nil
+}
+
+// NewChannelCreateUpdate creates config update for channel creation with orderer system channel.
+// Profile must contain consortium and application section
+func NewChannelCreateUpdate(channelID string, profile *Profile) (*common.ConfigUpdate, error) {
+	if profile.Application == nil {
+		return nil, ErrProfileApplicationMissing
+	}
+
+	if profile.Consortium == `` {
+		return nil, ErrProfileConsortiumMissing
+	}
+
+	channelGroup, err := NewChannelGroup(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	// template contains application orgs without values and policies of application group,
+	// so update is compared against orgs defined in consortium
+	template := proto.Clone(channelGroup).(*common.ConfigGroup)
+	template.Groups[channelconfig.ApplicationGroupKey].Values = nil
+	template.Groups[channelconfig.ApplicationGroupKey].Policies = nil
+
+	update, err := util.ComputeConfigUpdate(channelID, &common.Config{ChannelGroup: template}, &common.Config{ChannelGroup: channelGroup})
+	if err != nil {
+		return nil, fmt.Errorf(`compute update: %w`, err)
+	}
+
+	consortium, err := proto.Marshal(&common.Consortium{Name: profile.Consortium})
+	if err != nil {
+		return nil, fmt.Errorf(`marshal consortium: %w`, err)
+	}
+
+	if update.ReadSet.Values == nil {
+		update.ReadSet.Values = make(map[string]*common.ConfigValue)
+	}
+	if update.WriteSet.Values == nil {
+		update.WriteSet.Values = make(map[string]*common.ConfigValue)
+	}
+	update.ReadSet.Values[channelconfig.ConsortiumKey] = &common.ConfigValue{Version: 0}
+	update.WriteSet.Values[channelconfig.ConsortiumKey] = &common.ConfigValue{Version: 0, Value: consortium}
+
+	return update, nil
+}
+
+// NewChannelCreateTx creates channel creation transaction signed by signer
+func NewChannelCreateTx(channelID string, profile *Profile, signer msp.SigningIdentity) (*common.Envelope, error) {
+	update, err := NewChannelCreateUpdate(channelID, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope, err := NewUpdateEnvelope(update)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = SignUpdate(envelope, signer); err != nil {
+		return nil, err
+	}
+
+	return protoutil.CreateSignedEnvelope(common.HeaderType_CONFIG_UPDATE, channelID, signer, envelope, 0, 0)
+}
+
+// NewChannelGroup creates channel group from profile
+func NewChannelGroup(profile *Profile) (*common.ConfigGroup, error) {
+	channelGroup := newGroup()
+
+	setPolicies(channelGroup, profile.Policies, map[string]*common.Policy{
+		channelconfig.ReadersPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.ReadersPolicyKey),
+		channelconfig.WritersPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.WritersPolicyKey),
+		channelconfig.AdminsPolicyKey:  implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, channelconfig.AdminsPolicyKey),
+	})
+
+	values := []*channelconfig.StandardConfigValue{
+		channelconfig.HashingAlgorithmValue(),
+		channelconfig.BlockDataHashingStructureValue(),
+	}
+
+	if profile.Orderer != nil && len(profile.Orderer.Addresses) > 0 {
+		values = append(values, channelconfig.OrdererAddressesValue(profile.Orderer.Addresses))
+	}
+
+	if profile.Consortium != `` {
+		values = append(values, channelconfig.ConsortiumValue(profile.Consortium))
+	}
+
+	if len(profile.Capabilities) > 0 {
+		values = append(values, channelconfig.CapabilitiesValue(capabilities(profile.Capabilities)))
+	}
+
+	if err := setValues(channelGroup, values...); err != nil {
+		return nil, err
+	}
+
+	if profile.Orderer != nil {
+		ordererGroup, err := newOrdererGroup(profile.Orderer)
+		if err != nil {
+			return nil, fmt.Errorf(`orderer group: %w`, err)
+		}
+		channelGroup.Groups[channelconfig.OrdererGroupKey] = ordererGroup
+	}
+
+	if profile.Application != nil {
+		applicationGroup, err := newApplicationGroup(profile.Application)
+		if err != nil {
+			return nil, fmt.Errorf(`application group: %w`, err)
+		}
+		channelGroup.Groups[channelconfig.ApplicationGroupKey] = applicationGroup
+	}
+
+	return channelGroup, nil
+}
+
+func newOrdererGroup(profile *OrdererProfile) (*common.ConfigGroup, error) {
+	ordererGroup := newGroup()
+
+	setPolicies(ordererGroup, profile.Policies, map[string]*common.Policy{
+		channelconfig.ReadersPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.ReadersPolicyKey),
+		channelconfig.WritersPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.WritersPolicyKey),
+		channelconfig.AdminsPolicyKey:  implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, channelconfig.AdminsPolicyKey),
+		BlockValidationPolicyKey:       implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.WritersPolicyKey),
+	})
+
+	batchTimeout := profile.BatchTimeout
+	if batchTimeout == 0 {
+		batchTimeout = DefaultBatchTimeout
+	}
+
+	batchSize := profile.BatchSize
+	if batchSize == (BatchSize{}) {
+		batchSize = DefaultBatchSize
+	}
+
+	var (
+		ordererType       = profile.OrdererType
+		consensusMetadata []byte
+		err               error
+	)
+
+	switch ordererType {
+	case ``, ConsensusTypeEtcdRaft:
+		ordererType = ConsensusTypeEtcdRaft
+		options := profile.EtcdRaftOptions
+		if options == nil {
+			options = DefaultEtcdRaftOptions
+		}
+
+		if consensusMetadata, err = proto.Marshal(&etcdraft.ConfigMetadata{
+			Consenters: profile.Consenters,
+			Options:    options,
+		}); err != nil {
+			return nil, fmt.Errorf(`marshal etcdraft metadata: %w`, err)
+		}
+	case ConsensusTypeSolo:
+	default:
+		return nil, fmt.Errorf(`orderer type=%s: %w`, ordererType, ErrUnknownOrdererType)
+	}
+
+	values := []*channelconfig.StandardConfigValue{
+		channelconfig.BatchSizeValue(batchSize.MaxMessageCount, batchSize.AbsoluteMaxBytes, batchSize.PreferredMaxBytes),
+		channelconfig.BatchTimeoutValue(batchTimeout.String()),
+		channelconfig.ChannelRestrictionsValue(profile.MaxChannels),
+		channelconfig.ConsensusTypeValue(ordererType, consensusMetadata),
+	}
+
+	if len(profile.Capabilities) > 0 {
+		values = append(values, channelconfig.CapabilitiesValue(capabilities(profile.Capabilities)))
+	}
+
+	if err = setValues(ordererGroup, values...); err != nil {
+		return nil, err
+	}
+
+	for _, org := range profile.Orgs {
+		orgGroup, err := newOrgGroup(org)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(org.OrdererEndpoints) > 0 {
+			if err = setValues(orgGroup, channelconfig.EndpointsValue(org.OrdererEndpoints)); err != nil {
+				return nil, err
+			}
+		}
+
+		ordererGroup.Groups[org.name()] = orgGroup
+	}
+
+	return ordererGroup, nil
+}
+
+func newApplicationGroup(profile *ApplicationProfile) (*common.ConfigGroup, error) {
+	applicationGroup := newGroup()
+
+	setPolicies(applicationGroup, profile.Policies, map[string]*common.Policy{
+		channelconfig.ReadersPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.ReadersPolicyKey),
+		channelconfig.WritersPolicyKey: implicitMetaPolicy(common.ImplicitMetaPolicy_ANY, channelconfig.WritersPolicyKey),
+		channelconfig.AdminsPolicyKey:  implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, channelconfig.AdminsPolicyKey),
+		LifecycleEndorsementPolicyKey:  implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, EndorsementPolicyKey),
+		EndorsementPolicyKey:           implicitMetaPolicy(common.ImplicitMetaPolicy_MAJORITY, EndorsementPolicyKey),
+	})
+
+	var values []*channelconfig.StandardConfigValue
+	if len(profile.ACLs) > 0 {
+		values = append(values, channelconfig.ACLValues(profile.ACLs))
+	}
+
+	if len(profile.Capabilities) > 0 {
+		values = append(values, channelconfig.CapabilitiesValue(capabilities(profile.Capabilities)))
+	}
+
+	if err := setValues(applicationGroup, values...); err != nil {
+		return nil, err
+	}
+
+	for _, org := range profile.Orgs {
+		orgGroup, err := newOrgGroup(org)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(org.AnchorPeers) > 0 {
+			if err = setValues(orgGroup, channelconfig.AnchorPeersValue(org.AnchorPeers)); err != nil {
+				return nil, err
+			}
+		}
+
+		applicationGroup.Groups[org.name()] = orgGroup
+	}
+
+	return applicationGroup, nil
+}
+
+func newOrgGroup(org *OrgProfile) (*common.ConfigGroup, error) {
+	mspConfig, err := org.mspConfig()
+	if err != nil {
+		return nil, fmt.Errorf(`org=%s: %w`, org.name(), err)
+	}
+
+	orgGroup, err := NewOrgGroup(org.mspID(), mspConfig)
+	if err != nil {
+		return nil, fmt.Errorf(`org=%s: %w`, org.name(), err)
+	}
+
+	setPolicies(orgGroup, org.Policies, nil)
+
+	return orgGroup, nil
+}
+
+func (o *OrgProfile) name() string {
+	if o.Name != `` {
+		return o.Name
+	}
+	return o.mspID()
+}
+
+func (o *OrgProfile) mspID() string {
+	if o.MSPID == `` && o.MSP != nil {
+		return o.MSP.GetMSPIdentifier()
+	}
+	return o.MSPID
+}
+
+// mspConfig returns verifying MSP config, signing identity is not included
+func (o *OrgProfile) mspConfig() (*mspproto.MSPConfig, error) {
+	if o.MSP == nil {
+		if o.MSPDir == `` {
+			return nil, ErrOrgMSPMissing
+		}
+		if o.MSPID == `` {
+			return nil, fmt.Errorf(`msp dir=%s: %w`, o.MSPDir, ErrOrgMSPIDMissing)
+		}
+
+		return msp.GetVerifyingMspConfig(o.MSPDir, o.MSPID, msp.ProviderTypeToString(msp.FABRIC))
+	}
+
+	fabricMSPConfig := proto.Clone(o.MSP.MSPConfig()).(*mspproto.FabricMSPConfig)
+	fabricMSPConfig.Name = o.mspID()
+	fabricMSPConfig.SigningIdentity = nil
+
+	configBytes, err := proto.Marshal(fabricMSPConfig)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal fabric MSP config: %w`, err)
+	}
+
+	return &mspproto.MSPConfig{Type: int32(msp.FABRIC), Config: configBytes}, nil
+}
+
+func newGroup() *common.ConfigGroup {
+	return &common.ConfigGroup{
+		ModPolicy: channelconfig.AdminsPolicyKey,
+		Groups:    make(map[string]*common.ConfigGroup),
+		Values:    make(map[string]*common.ConfigValue),
+		Policies:  make(map[string]*common.ConfigPolicy),
+	}
+}
+
+// setPolicies sets default policies to group, policies override defaults with the same name
+func setPolicies(group *common.ConfigGroup, policies, defaults map[string]*common.Policy) {
+	for _, set := range []map[string]*common.Policy{defaults, policies} {
+		for name, policy := range set {
+			group.Policies[name] = &common.ConfigPolicy{ModPolicy: channelconfig.AdminsPolicyKey, Policy: policy}
+		}
+	}
+}
+
+func setValues(group *common.ConfigGroup, values ...*channelconfig.StandardConfigValue) error {
+	for _, value := range values {
+		if err := setValue(group, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func implicitMetaPolicy(rule common.ImplicitMetaPolicy_Rule, subPolicy string) *common.Policy {
+	return &common.Policy{
+		Type:  int32(common.Policy_IMPLICIT_META),
+		Value: protoutil.MarshalOrPanic(&common.ImplicitMetaPolicy{Rule: rule, SubPolicy: subPolicy}),
+	}
+}
+
+func capabilities(names []string) map[string]bool {
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		enabled[name] = true
+	}
+	return enabled
+}
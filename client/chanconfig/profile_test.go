@@ -0,0 +1,196 @@
+package chanconfig_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer/etcdraft"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/client/chanconfig"
+	"github.com/vitiko/hlf-sdk-go/client/osnadmin"
+	"github.com/vitiko/hlf-sdk-go/client/osnadmin/osnadmintest"
+	"github.com/vitiko/hlf-sdk-go/identity"
+)
+
+func profile(t *testing.T) *chanconfig.Profile {
+	tlsCert, err := ioutil.ReadFile(peerMSPPath + `/signcerts/cert.pem`)
+	if err != nil {
+		t.Fatalf("read cert: %s", err)
+	}
+
+	org1MSP, err := identity.MSPFromPath(`Org1MSP`, peerMSPPath)
+	if err != nil {
+		t.Fatalf("load msp: %s", err)
+	}
+
+	return &chanconfig.Profile{
+		Capabilities: []string{`V2_0`},
+		Orderer: &chanconfig.OrdererProfile{
+			Consenters: []*etcdraft.Consenter{{
+				Host: `orderer1.example.com`, Port: 7050, ClientTlsCert: tlsCert, ServerTlsCert: tlsCert,
+			}},
+			Orgs: []*chanconfig.OrgProfile{{
+				MSPID:            `OrdererMSP`,
+				MSPDir:           peerMSPPath,
+				OrdererEndpoints: []string{`orderer1.example.com:7050`},
+			}},
+			Capabilities: []string{`V2_0`},
+		},
+		Application: &chanconfig.ApplicationProfile{
+			Orgs: []*chanconfig.OrgProfile{{
+				MSP:         org1MSP,
+				AnchorPeers: []*peer.AnchorPeer{{Host: `peer0.org1.example.com`, Port: 7051}},
+			}},
+			Capabilities: []string{`V2_0`},
+		},
+	}
+}
+
+func TestNewGenesisBlock(t *testing.T) {
+	block, err := chanconfig.NewGenesisBlock(channelID, profile(t))
+	if err != nil {
+		t.Fatalf("genesis block: %s", err)
+	}
+
+	envelope, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		t.Fatalf("extract envelope: %s", err)
+	}
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		t.Fatalf("crypto provider: %s", err)
+	}
+
+	bundle, err := channelconfig.NewBundleFromEnvelope(envelope, cryptoProvider)
+	if err != nil {
+		t.Fatalf("bundle from genesis block: %s", err)
+	}
+
+	ordererConfig, ok := bundle.OrdererConfig()
+	if !ok || ordererConfig.ConsensusType() != chanconfig.ConsensusTypeEtcdRaft {
+		t.Fatalf("unexpected orderer config: %+v", ordererConfig)
+	}
+	if ordererConfig.Organizations()[`OrdererMSP`].Endpoints()[0] != `orderer1.example.com:7050` {
+		t.Fatal(`orderer org endpoints not set`)
+	}
+
+	applicationConfig, ok := bundle.ApplicationConfig()
+	if !ok {
+		t.Fatal(`application config not found`)
+	}
+	org1, ok := applicationConfig.Organizations()[`Org1MSP`]
+	if !ok || len(org1.AnchorPeers()) != 1 {
+		t.Fatalf("unexpected application org: %+v", org1)
+	}
+
+	server := osnadmintest.NewServer()
+	defer server.Close()
+
+	admin, err := server.Admin()
+	if err != nil {
+		t.Fatalf("orderer admin: %s", err)
+	}
+
+	info, err := admin.Join(context.Background(), block)
+	if err != nil {
+		t.Fatalf("join: %s", err)
+	}
+	if info.Name != channelID || info.Status != osnadmin.StatusActive {
+		t.Fatalf("unexpected joined channel info: %+v", info)
+	}
+}
+
+func TestNewChannelCreateTx(t *testing.T) {
+	p := profile(t)
+	if _, err := chanconfig.NewChannelCreateTx(channelID, p, signer(t, adminMSPPath)); !errors.Is(err, chanconfig.ErrProfileConsortiumMissing) {
+		t.Fatalf("create tx without consortium: expected= %s, got= %v", chanconfig.ErrProfileConsortiumMissing, err)
+	}
+
+	p.Consortium = `SampleConsortium`
+	tx, err := chanconfig.NewChannelCreateTx(channelID, p, signer(t, adminMSPPath))
+	if err != nil {
+		t.Fatalf("create tx: %s", err)
+	}
+
+	envelope, err := protoutil.EnvelopeToConfigUpdate(tx)
+	if err != nil {
+		t.Fatalf("config update envelope: %s", err)
+	}
+	if len(envelope.Signatures) != 1 {
+		t.Fatalf("signatures: expected= 1, got= %d", len(envelope.Signatures))
+	}
+
+	update := &common.ConfigUpdate{}
+	if err = proto.Unmarshal(envelope.ConfigUpdate, update); err != nil {
+		t.Fatalf("unmarshal config update: %s", err)
+	}
+
+	if update.ChannelId != channelID {
+		t.Fatalf("channel id: expected= %s, got= %s", channelID, update.ChannelId)
+	}
+
+	consortium := &common.Consortium{}
+	if err = proto.Unmarshal(update.WriteSet.Values[channelconfig.ConsortiumKey].GetValue(), consortium); err != nil || consortium.Name != `SampleConsortium` {
+		t.Fatalf("unexpected consortium in write set: %v, %v", consortium, err)
+	}
+
+	application := update.WriteSet.Groups[channelconfig.ApplicationGroupKey]
+	if application.GetVersion() != 1 {
+		t.Fatalf("application group version: expected= 1, got= %d", application.GetVersion())
+	}
+	if _, ok := application.Groups[`Org1MSP`]; !ok {
+		t.Fatal(`application org not found in write set`)
+	}
+	if _, ok := update.ReadSet.Groups[channelconfig.ApplicationGroupKey].GetGroups()[`Org1MSP`]; !ok {
+		t.Fatal(`application org not found in read set`)
+	}
+}
+
+func TestNewConfig_Policies(t *testing.T) {
+	p := profile(t)
+	writers := &common.Policy{
+		Type:  int32(common.Policy_SIGNATURE),
+		Value: protoutil.MarshalOrPanic(policydsl.SignedByMspAdmin(`OrdererMSP`)),
+	}
+	p.Orderer.Policies = map[string]*common.Policy{channelconfig.WritersPolicyKey: writers}
+	p.Orderer.Orgs[0].Policies = map[string]*common.Policy{channelconfig.WritersPolicyKey: writers}
+
+	config, err := chanconfig.NewConfig(p)
+	if err != nil {
+		t.Fatalf("new config: %s", err)
+	}
+
+	orderer := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	for _, group := range []*common.ConfigGroup{orderer, orderer.Groups[`OrdererMSP`]} {
+		if !proto.Equal(group.Policies[channelconfig.WritersPolicyKey].GetPolicy(), writers) {
+			t.Fatalf("writers policy: expected= %v, got= %v", writers, group.Policies[channelconfig.WritersPolicyKey])
+		}
+		if _, ok := group.Policies[channelconfig.AdminsPolicyKey]; !ok {
+			t.Fatal(`default admins policy not set`)
+		}
+	}
+
+	if _, ok := orderer.Policies[chanconfig.BlockValidationPolicyKey]; !ok {
+		t.Fatal(`default block validation policy not set`)
+	}
+}
+
+func TestNewConfig_OrgMSPIDMissing(t *testing.T) {
+	p := profile(t)
+	p.Orderer.Orgs[0].MSPID = ``
+	p.Orderer.Orgs[0].Name = `OrdererOrg`
+
+	if _, err := chanconfig.NewConfig(p); !errors.Is(err, chanconfig.ErrOrgMSPIDMissing) {
+		t.Fatalf("new config: expected= %s, got= %v", chanconfig.ErrOrgMSPIDMissing, err)
+	}
+}
@@ -0,0 +1,239 @@
+package chanconfig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+var (
+	ErrConfigUpdateMismatch      = errors.New(`config update differs between envelopes`)
+	ErrNoEnvelopes               = errors.New(`no config update envelopes`)
+	ErrConfigUpdateNotAuthorized = errors.New(`config update is not authorized`)
+)
+
+// NewUpdateEnvelope creates envelope with config update and without signatures,
+// envelope can be written to file and passed to admins of each org for signing
+func NewUpdateEnvelope(update *common.ConfigUpdate) (*common.ConfigUpdateEnvelope, error) {
+	updateBytes, err := proto.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal config update: %w`, err)
+	}
+
+	return &common.ConfigUpdateEnvelope{ConfigUpdate: updateBytes}, nil
+}
+
+// ReadUpdateEnvelope reads config update envelope from file in protobuf binary format
+func ReadUpdateEnvelope(path string) (*common.ConfigUpdateEnvelope, error) {
+	envelopeBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf(`read file=%s: %w`, path, err)
+	}
+
+	envelope := &common.ConfigUpdateEnvelope{}
+	if err = proto.Unmarshal(envelopeBytes, envelope); err != nil {
+		return nil, fmt.Errorf(`unmarshal config update envelope from file=%s: %w`, path, err)
+	}
+
+	return envelope, nil
+}
+
+// WriteUpdateEnvelope writes config update envelope to file in protobuf binary format
+func WriteUpdateEnvelope(path string, envelope *common.ConfigUpdateEnvelope) error {
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf(`marshal config update envelope: %w`, err)
+	}
+
+	if err = ioutil.WriteFile(path, envelopeBytes, 0644); err != nil {
+		return fmt.Errorf(`write file=%s: %w`, path, err)
+	}
+
+	return nil
+}
+
+// SignUpdate adds signature of identity to envelope, previous signature of the same identity is replaced
+func SignUpdate(envelope *common.ConfigUpdateEnvelope, signer msp.SigningIdentity) error {
+	creator, err := signer.Serialize()
+	if err != nil {
+		return fmt.Errorf(`serialize identity: %w`, err)
+	}
+
+	nonce, err := protoutil.CreateNonce()
+	if err != nil {
+		return fmt.Errorf(`nonce: %w`, err)
+	}
+
+	signatureHeader, err := hlfproto.NewMarshalledSignatureHeader(creator, nonce)
+	if err != nil {
+		return fmt.Errorf(`signature header: %w`, err)
+	}
+
+	signature, err := signer.Sign(append(append([]byte{}, signatureHeader...), envelope.ConfigUpdate...))
+	if err != nil {
+		return fmt.Errorf(`sign config update: %w`, err)
+	}
+
+	signatures, err := withoutCreator(envelope.Signatures, creator)
+	if err != nil {
+		return err
+	}
+
+	envelope.Signatures = append(signatures, &common.ConfigSignature{
+		SignatureHeader: signatureHeader,
+		Signature:       signature,
+	})
+
+	return nil
+}
+
+// MergeSignatures combines signatures from envelopes with the same config update,
+// signature of each identity is included once
+func MergeSignatures(envelopes ...*common.ConfigUpdateEnvelope) (*common.ConfigUpdateEnvelope, error) {
+	if len(envelopes) == 0 {
+		return nil, ErrNoEnvelopes
+	}
+
+	merged := &common.ConfigUpdateEnvelope{ConfigUpdate: envelopes[0].ConfigUpdate}
+	for i, envelope := range envelopes {
+		if !bytes.Equal(envelope.ConfigUpdate, merged.ConfigUpdate) {
+			return nil, fmt.Errorf(`envelope=%d: %w`, i, ErrConfigUpdateMismatch)
+		}
+
+		for _, signature := range envelope.Signatures {
+			creator, err := signatureCreator(signature)
+			if err != nil {
+				return nil, fmt.Errorf(`envelope=%d: %w`, i, err)
+			}
+
+			if merged.Signatures, err = withoutCreator(merged.Signatures, creator); err != nil {
+				return nil, err
+			}
+			merged.Signatures = append(merged.Signatures, signature)
+		}
+	}
+
+	return merged, nil
+}
+
+// UpdateSigners returns identities signed config update
+func UpdateSigners(envelope *common.ConfigUpdateEnvelope) ([]*mspproto.SerializedIdentity, error) {
+	var signers []*mspproto.SerializedIdentity
+	for _, signature := range envelope.Signatures {
+		creator, err := signatureCreator(signature)
+		if err != nil {
+			return nil, err
+		}
+
+		signer := &mspproto.SerializedIdentity{}
+		if err = proto.Unmarshal(creator, signer); err != nil {
+			return nil, fmt.Errorf(`unmarshal signer identity: %w`, err)
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
+
+// VerifyUpdate checks config update against current channel config, as orderer does:
+// read set versions must match config and signatures must satisfy mod policies of modified elements.
+// Returns ErrConfigUpdateNotAuthorized if update can't be applied with collected signatures
+func VerifyUpdate(config *common.Config, envelope *common.ConfigUpdateEnvelope) error {
+	update := &common.ConfigUpdate{}
+	if err := proto.Unmarshal(envelope.ConfigUpdate, update); err != nil {
+		return fmt.Errorf(`unmarshal config update: %w`, err)
+	}
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return fmt.Errorf(`crypto provider: %w`, err)
+	}
+
+	bundle, err := channelconfig.NewBundle(update.ChannelId, config, cryptoProvider)
+	if err != nil {
+		return fmt.Errorf(`channel config bundle: %w`, err)
+	}
+
+	envelopeBytes, err := proto.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf(`marshal config update envelope: %w`, err)
+	}
+
+	channelHeader, err := proto.Marshal(&common.ChannelHeader{
+		Type:      int32(common.HeaderType_CONFIG_UPDATE),
+		ChannelId: update.ChannelId,
+	})
+	if err != nil {
+		return fmt.Errorf(`marshal channel header: %w`, err)
+	}
+
+	payload, err := hlfproto.NewMarshalledCommonPayload(&common.Header{ChannelHeader: channelHeader}, envelopeBytes)
+	if err != nil {
+		return fmt.Errorf(`payload: %w`, err)
+	}
+
+	if _, err = bundle.ConfigtxValidator().ProposeConfigUpdate(&common.Envelope{Payload: payload}); err != nil {
+		return fmt.Errorf(`%s: %w`, err, ErrConfigUpdateNotAuthorized)
+	}
+
+	return nil
+}
+
+// SubmitUpdate sends signed config update to orderer, envelope is signed by submitter
+func SubmitUpdate(
+	ctx context.Context, orderer api.Orderer, envelope *common.ConfigUpdateEnvelope, submitter msp.SigningIdentity) error {
+
+	update := &common.ConfigUpdate{}
+	if err := proto.Unmarshal(envelope.ConfigUpdate, update); err != nil {
+		return fmt.Errorf(`unmarshal config update: %w`, err)
+	}
+
+	signedEnvelope, err := protoutil.CreateSignedEnvelope(
+		common.HeaderType_CONFIG_UPDATE, update.ChannelId, submitter, envelope, 0, 0)
+	if err != nil {
+		return fmt.Errorf(`create signed envelope: %w`, err)
+	}
+
+	if _, err = orderer.Broadcast(ctx, signedEnvelope); err != nil {
+		return fmt.Errorf(`broadcast config update: %w`, err)
+	}
+
+	return nil
+}
+
+func signatureCreator(signature *common.ConfigSignature) ([]byte, error) {
+	header := &common.SignatureHeader{}
+	if err := proto.Unmarshal(signature.SignatureHeader, header); err != nil {
+		return nil, fmt.Errorf(`unmarshal signature header: %w`, err)
+	}
+	return header.Creator, nil
+}
+
+func withoutCreator(signatures []*common.ConfigSignature, creator []byte) ([]*common.ConfigSignature, error) {
+	var filtered []*common.ConfigSignature
+	for _, signature := range signatures {
+		existing, err := signatureCreator(signature)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(existing, creator) {
+			filtered = append(filtered, signature)
+		}
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,171 @@
+package chanconfig_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/orderer"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/client/chanconfig"
+	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
+	"github.com/vitiko/hlf-sdk-go/identity"
+)
+
+const (
+	peerMSPPath  = `../../identity/testdata/Org1MSPPeer`
+	adminMSPPath = `../../identity/testdata/Org1MSPAdmin`
+)
+
+type broadcastOrderer struct {
+	envelopes []*common.Envelope
+}
+
+func (o *broadcastOrderer) Broadcast(_ context.Context, envelope *common.Envelope) (*orderer.BroadcastResponse, error) {
+	o.envelopes = append(o.envelopes, envelope)
+	return &orderer.BroadcastResponse{Status: common.Status_SUCCESS}, nil
+}
+
+func (o *broadcastOrderer) Deliver(context.Context, *common.Envelope) (*common.Block, error) {
+	return nil, errors.New(`not implemented`)
+}
+
+func (o *broadcastOrderer) GetConfigBlock(context.Context, msp.SigningIdentity, string) (*common.Block, error) {
+	return nil, errors.New(`not implemented`)
+}
+
+func signer(t *testing.T, mspPath string) msp.SigningIdentity {
+	id, err := identity.SignerFromMSPPath(`Org1MSP`, mspPath)
+	if err != nil {
+		t.Fatalf("load identity: %s", err)
+	}
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatalf("crypto suite: %s", err)
+	}
+	return id.GetSigningIdentity(cs)
+}
+
+// applicationConfig returns channel config with Org1MSP application org
+func applicationConfig(t *testing.T) *common.Config {
+	config, err := chanconfig.NewEditor(channelID, &common.Config{
+		ChannelGroup: &common.ConfigGroup{
+			ModPolicy: `Admins`,
+			Values: map[string]*common.ConfigValue{
+				channelconfig.HashingAlgorithmKey: {ModPolicy: `Admins`,
+					Value: marshal(t, channelconfig.HashingAlgorithmValue().Value())},
+				channelconfig.BlockDataHashingStructureKey: {ModPolicy: `Admins`,
+					Value: marshal(t, channelconfig.BlockDataHashingStructureValue().Value())},
+				channelconfig.OrdererAddressesKey: {ModPolicy: `Admins`,
+					Value: marshal(t, channelconfig.OrdererAddressesValue([]string{`orderer1.example.com:7050`}).Value())},
+			},
+			Groups: map[string]*common.ConfigGroup{
+				channelconfig.ApplicationGroupKey: {ModPolicy: `Admins`},
+			},
+		},
+	}).
+		// admin OU is supported by MSP since V1_4_3 channel capability
+		AddCapability(`V2_0`).
+		AddApplicationOrgFromMSPDir(`Org1MSP`, peerMSPPath).
+		Config()
+	if err != nil {
+		t.Fatalf("config: %s", err)
+	}
+
+	return config
+}
+
+func TestSignatures(t *testing.T) {
+	config := applicationConfig(t)
+	update, err := chanconfig.NewEditor(channelID, config).
+		SetAnchorPeers(`Org1MSP`, &peer.AnchorPeer{Host: `peer0.org1.example.com`, Port: 7051}).
+		Build()
+	if err != nil {
+		t.Fatalf("build update: %s", err)
+	}
+
+	envelope, err := chanconfig.NewUpdateEnvelope(update)
+	if err != nil {
+		t.Fatalf("new update envelope: %s", err)
+	}
+
+	proposalFile := filepath.Join(t.TempDir(), `update.pb`)
+	if err = chanconfig.WriteUpdateEnvelope(proposalFile, envelope); err != nil {
+		t.Fatalf("write proposal: %s", err)
+	}
+
+	// signatures are added independently to copies of proposal
+	signed := make([]*common.ConfigUpdateEnvelope, 0)
+	for _, mspPath := range []string{peerMSPPath, adminMSPPath} {
+		proposal, err := chanconfig.ReadUpdateEnvelope(proposalFile)
+		if err != nil {
+			t.Fatalf("read proposal: %s", err)
+		}
+
+		if err = chanconfig.SignUpdate(proposal, signer(t, mspPath)); err != nil {
+			t.Fatalf("sign update: %s", err)
+		}
+		signed = append(signed, proposal)
+	}
+
+	// org mod policy Admins is not satisfied with peer signature
+	if err = chanconfig.VerifyUpdate(config, signed[0]); !errors.Is(err, chanconfig.ErrConfigUpdateNotAuthorized) {
+		t.Fatalf("verify peer signed update: expected= %s, got= %v", chanconfig.ErrConfigUpdateNotAuthorized, err)
+	}
+
+	// admin signs again, signature is replaced
+	if err = chanconfig.SignUpdate(signed[1], signer(t, adminMSPPath)); err != nil {
+		t.Fatalf("sign update: %s", err)
+	}
+
+	merged, err := chanconfig.MergeSignatures(signed...)
+	if err != nil {
+		t.Fatalf("merge signatures: %s", err)
+	}
+
+	signers, err := chanconfig.UpdateSigners(merged)
+	if err != nil {
+		t.Fatalf("update signers: %s", err)
+	}
+	if len(signers) != 2 {
+		t.Fatalf("signers: expected= 2, got= %d", len(signers))
+	}
+
+	if err = chanconfig.VerifyUpdate(config, merged); err != nil {
+		t.Fatalf("verify merged update: %s", err)
+	}
+
+	ord := &broadcastOrderer{}
+	if err = chanconfig.SubmitUpdate(context.Background(), ord, merged, signer(t, adminMSPPath)); err != nil {
+		t.Fatalf("submit update: %s", err)
+	}
+
+	submitted, err := protoutil.EnvelopeToConfigUpdate(ord.envelopes[0])
+	if err != nil {
+		t.Fatalf("submitted envelope: %s", err)
+	}
+	if !proto.Equal(submitted, merged) {
+		t.Fatal(`submitted update differs from merged`)
+	}
+
+	otherUpdate, err := chanconfig.NewEditor(channelID, config).RemoveApplicationOrg(`Org1MSP`).Build()
+	if err != nil {
+		t.Fatalf("build update: %s", err)
+	}
+	otherEnvelope, err := chanconfig.NewUpdateEnvelope(otherUpdate)
+	if err != nil {
+		t.Fatalf("new update envelope: %s", err)
+	}
+
+	if _, err = chanconfig.MergeSignatures(merged, otherEnvelope); !errors.Is(err, chanconfig.ErrConfigUpdateMismatch) {
+		t.Fatalf("merge different updates: expected= %s, got= %v", chanconfig.ErrConfigUpdateMismatch, err)
+	}
+}
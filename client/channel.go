@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -28,12 +29,29 @@ type Channel struct {
 	chaincodesMx sync.Mutex
 	dp           api.DiscoveryProvider
 	identity     msp.SigningIdentity
-	fabricV2     bool
-	log          *zap.Logger
+	// adminIdentity signs peer join requests, identity is used if not set
+	adminIdentity msp.SigningIdentity
+	fabricV2      bool
+	log           *zap.Logger
 }
 
+type ChannelOpt func(*Channel)
+
 var _ api.Channel = (*Channel)(nil)
 
+var (
+	ErrMSPPeersNotFound = errors.New(`no peers for msp`)
+	ErrPeersJoinFailed  = errors.New(`peers join failed`)
+)
+
+// WithAdminIdentity sets identity for admin-only channel operations (peer join),
+// e.g. signing identity of identity.MSPWithRoles Admin
+func WithAdminIdentity(adminIdentity msp.SigningIdentity) ChannelOpt {
+	return func(c *Channel) {
+		c.adminIdentity = adminIdentity
+	}
+}
+
 // Chaincode - returns interface with actions over chaincode
 // ctx is necessary for service discovery
 func (c *Channel) Chaincode(serviceDiscCtx context.Context, ccName string) (api.Chaincode, error) {
@@ -109,8 +127,9 @@ func NewChannel(
 	identity msp.SigningIdentity,
 	fabricV2 bool,
 	log *zap.Logger,
+	opts ...ChannelOpt,
 ) api.Channel {
-	return &Channel{
+	c := &Channel{
 		mspId:      mspId,
 		chanName:   chanName,
 		peerPool:   peerPool,
@@ -121,19 +140,25 @@ func NewChannel(
 		fabricV2:   fabricV2,
 		log:        log,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
+// Join joins first peer of current MSP to channel, JoinAll should be used to join all MSP peers
 func (c *Channel) Join(ctx context.Context) error {
 	channelGenesis, err := c.getGenesisBlockFromOrderer(ctx)
 	if err != nil {
 		return fmt.Errorf(`get genesis block from orderer: %w`, err)
 	}
 
-	// todo: refactor
 	peers := c.peerPool.GetMSPPeers(c.mspId)
 
 	if len(peers) == 0 {
-		return fmt.Errorf(`no peeers for msp if=%s`, c.mspId)
+		return fmt.Errorf(`msp id=%s: %w`, c.mspId, ErrMSPPeersNotFound)
 	}
 
 	cscc := system.NewCSCC(
@@ -141,7 +166,7 @@ func (c *Channel) Join(ctx context.Context) error {
 		peers[0],
 		proto.FabricVersionIsV2(c.fabricV2))
 
-	_, err = cscc.JoinChain(ctx, &system.JoinChainRequest{
+	_, err = cscc.JoinChain(c.adminContext(ctx), &system.JoinChainRequest{
 		Channel:      c.chanName,
 		GenesisBlock: channelGenesis,
 	})
@@ -149,6 +174,110 @@ func (c *Channel) Join(ctx context.Context) error {
 	return err
 }
 
+// JoinAll joins all peers of current MSP to channel in parallel, peers already joined are skipped.
+// Genesis block is fetched from orderer only if some peer is not joined yet
+func (c *Channel) JoinAll(ctx context.Context) ([]api.PeerJoinResult, error) {
+	var (
+		genesisOnce sync.Once
+		genesis     *common.Block
+		genesisErr  error
+	)
+
+	return c.joinPeers(ctx, func(ctx context.Context, cscc *system.CSCCService) error {
+		genesisOnce.Do(func() {
+			genesis, genesisErr = c.getGenesisBlockFromOrderer(ctx)
+		})
+		if genesisErr != nil {
+			return fmt.Errorf(`get genesis block from orderer: %w`, genesisErr)
+		}
+
+		_, err := cscc.JoinChain(ctx, &system.JoinChainRequest{
+			Channel:      c.chanName,
+			GenesisBlock: genesis,
+		})
+		return err
+	})
+}
+
+// JoinBySnapshot joins all peers of current MSP to channel from snapshot, peers already joined are skipped.
+// snapshotDir is path to snapshot on peers file system, join by snapshot is supported since HLF Peer V2.3
+func (c *Channel) JoinBySnapshot(ctx context.Context, snapshotDir string) ([]api.PeerJoinResult, error) {
+	return c.joinPeers(ctx, func(ctx context.Context, cscc *system.CSCCService) error {
+		return cscc.JoinBySnapshot(ctx, snapshotDir)
+	})
+}
+
+// joinPeers calls join for each MSP peer not joined to channel, returns results in order of pool peers
+func (c *Channel) joinPeers(
+	ctx context.Context, join func(context.Context, *system.CSCCService) error) ([]api.PeerJoinResult, error) {
+
+	peers := c.peerPool.GetMSPPeers(c.mspId)
+	if len(peers) == 0 {
+		return nil, fmt.Errorf(`msp id=%s: %w`, c.mspId, ErrMSPPeersNotFound)
+	}
+
+	results := make([]api.PeerJoinResult, len(peers))
+	wg := sync.WaitGroup{}
+
+	for i := range peers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.joinPeer(ctx, peers[i], join)
+		}(i)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf(`channel=%s, failed %d of %d: %w`, c.chanName, failed, len(peers), ErrPeersJoinFailed)
+	}
+
+	return results, nil
+}
+
+func (c *Channel) joinPeer(
+	ctx context.Context, p api.Peer, join func(context.Context, *system.CSCCService) error) api.PeerJoinResult {
+
+	result := api.PeerJoinResult{Peer: p.Uri()}
+
+	channels, err := p.GetChannels(ctx)
+	if err != nil {
+		result.Err = fmt.Errorf(`get channels: %w`, err)
+		return result
+	}
+
+	for _, ch := range channels.GetChannels() {
+		if ch.ChannelId == c.chanName {
+			result.AlreadyJoined = true
+			return result
+		}
+	}
+
+	if err = join(c.adminContext(ctx), system.NewCSCC(p, proto.FabricVersionIsV2(c.fabricV2))); err != nil {
+		result.Err = fmt.Errorf(`join: %w`, err)
+		return result
+	}
+
+	c.log.Debug(`peer joined to channel`, zap.String(`channel`, c.chanName), zap.String(`peer`, result.Peer))
+
+	return result
+}
+
+// adminContext sets admin identity as request signer, signer already set in context is retained
+func (c *Channel) adminContext(ctx context.Context) context.Context {
+	if c.adminIdentity == nil || tx.SignerFromContext(ctx) != nil {
+		return ctx
+	}
+	return tx.ContextWithSigner(ctx, c.adminIdentity)
+}
+
 func (c *Channel) getGenesisBlockFromOrderer(ctx context.Context) (*common.Block, error) {
 	requestBlockEnvelope, err := tx.NewSeekGenesisEnvelope(c.chanName, c.identity, nil)
 	if err != nil {
@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	peerproto "github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/msp"
+	"go.uber.org/zap"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/client/chaincode/system"
+	"github.com/vitiko/hlf-sdk-go/client/tx"
+	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
+	"github.com/vitiko/hlf-sdk-go/identity"
+)
+
+type (
+	// joinPeer records CSCC queries, other peer methods are not used in join
+	joinPeer struct {
+		api.Peer
+		uri      string
+		channels []string
+
+		mu      sync.Mutex
+		queries [][][]byte
+		signers []msp.SigningIdentity
+	}
+
+	joinPool struct {
+		api.PeerPool
+		peers []api.Peer
+	}
+
+	joinOrderer struct {
+		api.Orderer
+		genesis  *common.Block
+		delivers int
+	}
+)
+
+func (p *joinPeer) Uri() string {
+	return p.uri
+}
+
+func (p *joinPeer) GetChannels(context.Context) (*peerproto.ChannelQueryResponse, error) {
+	res := &peerproto.ChannelQueryResponse{}
+	for _, ch := range p.channels {
+		res.Channels = append(res.Channels, &peerproto.ChannelInfo{ChannelId: ch})
+	}
+	return res, nil
+}
+
+func (p *joinPeer) Query(ctx context.Context, _, chaincode string, args [][]byte, _ msp.SigningIdentity, _ map[string][]byte) (*peerproto.Response, error) {
+	if chaincode != system.CSCCName {
+		return nil, errors.New(`unexpected chaincode ` + chaincode)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.queries = append(p.queries, args)
+	p.signers = append(p.signers, tx.SignerFromContext(ctx))
+
+	return &peerproto.Response{Status: 200}, nil
+}
+
+func (p *joinPool) GetMSPPeers(string) []api.Peer {
+	return p.peers
+}
+
+func (o *joinOrderer) Deliver(context.Context, *common.Envelope) (*common.Block, error) {
+	o.delivers++
+	return o.genesis, nil
+}
+
+func newJoinChannel(t *testing.T, peers ...api.Peer) (*Channel, *joinOrderer) {
+	signer, err := identity.SignerFromMSPPath(`Org1MSP`, `../identity/testdata/Org1MSPPeer`)
+	if err != nil {
+		t.Fatalf("load signer: %s", err)
+	}
+
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatalf("crypto suite: %s", err)
+	}
+
+	orderer := &joinOrderer{genesis: &common.Block{Header: &common.BlockHeader{Number: 0}}}
+
+	return &Channel{
+		mspId:    `Org1MSP`,
+		chanName: `channel1`,
+		peerPool: &joinPool{peers: peers},
+		orderer:  orderer,
+		identity: signer.GetSigningIdentity(cs),
+		fabricV2: true,
+		log:      zap.NewNop(),
+	}, orderer
+}
+
+func TestChannel_JoinAll(t *testing.T) {
+	joined := &joinPeer{uri: `peer0`, channels: []string{`channel1`}}
+	notJoined := &joinPeer{uri: `peer1`}
+
+	channel, orderer := newJoinChannel(t, joined, notJoined)
+
+	results, err := channel.JoinAll(context.Background())
+	if err != nil {
+		t.Fatalf("join all: %s", err)
+	}
+
+	if !results[0].AlreadyJoined || results[1].AlreadyJoined {
+		t.Fatalf("unexpected join results: %+v", results)
+	}
+
+	if len(joined.queries) != 0 {
+		t.Fatalf("joined peer received queries: %d", len(joined.queries))
+	}
+
+	if orderer.delivers != 1 {
+		t.Fatalf("genesis block fetched %d times, expected 1", orderer.delivers)
+	}
+
+	if len(notJoined.queries) != 1 {
+		t.Fatalf("expected 1 query, got %d", len(notJoined.queries))
+	}
+
+	args := notJoined.queries[0]
+	if len(args) != 2 || string(args[0]) != `JoinChain` {
+		t.Fatalf("unexpected join args: %q", args)
+	}
+
+	genesis := &common.Block{}
+	if err = proto.Unmarshal(args[1], genesis); err != nil {
+		t.Fatalf("unmarshal genesis block: %s", err)
+	}
+	if !proto.Equal(genesis, orderer.genesis) {
+		t.Fatalf("unexpected genesis block: %v", genesis)
+	}
+}
+
+func TestChannel_JoinBySnapshot(t *testing.T) {
+	p := &joinPeer{uri: `peer0`}
+	channel, orderer := newJoinChannel(t, p)
+
+	mspConfig, err := identity.MSPFromPath(`Org1MSP`, `../identity/testdata/Org1MSPPeer`,
+		identity.WithAdminMSPPath(`../identity/testdata/Org1MSPAdmin`))
+	if err != nil {
+		t.Fatalf("load msp: %s", err)
+	}
+	admin, err := mspConfig.Admin()
+	if err != nil {
+		t.Fatalf("msp admin: %s", err)
+	}
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatalf("crypto suite: %s", err)
+	}
+	adminIdentity := admin.GetSigningIdentity(cs)
+	WithAdminIdentity(adminIdentity)(channel)
+
+	if _, err = channel.JoinBySnapshot(context.Background(), `/var/snapshots/channel1/100`); err != nil {
+		t.Fatalf("join by snapshot: %s", err)
+	}
+
+	if orderer.delivers != 0 {
+		t.Fatalf("genesis block should not be fetched on join by snapshot")
+	}
+
+	if len(p.queries) != 1 {
+		t.Fatalf("expected 1 query, got %d", len(p.queries))
+	}
+
+	args := p.queries[0]
+	// function name registered by CSCC of HLF Peer V2.3+
+	if len(args) != 2 || string(args[0]) != `JoinChainBySnapshot` || string(args[1]) != `/var/snapshots/channel1/100` {
+		t.Fatalf("unexpected join by snapshot args: %q", args)
+	}
+
+	if p.signers[0] != adminIdentity {
+		t.Fatal(`join by snapshot is not signed by admin identity`)
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/vitiko/hlf-sdk-go/crypto"
 	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
 	"github.com/vitiko/hlf-sdk-go/discovery"
+	"github.com/vitiko/hlf-sdk-go/identity"
 )
 
 // implementation of api.Core interface
@@ -26,6 +27,8 @@ type core struct {
 	logger            *zap.Logger
 	config            *config.Config
 	identity          msp.SigningIdentity
+	adminMSP          identity.MSPWithRoles
+	adminIdentity     msp.SigningIdentity
 	peerPool          api.PeerPool
 	orderer           api.Orderer
 	discoveryProvider api.DiscoveryProvider
@@ -108,7 +111,13 @@ func (c *core) Channel(name string) api.Channel {
 		ord = c.orderer
 	}
 
-	ch = NewChannel(c.identity.GetMSPIdentifier(), name, c.peerPool, ord, c.discoveryProvider, c.identity, c.fabricV2, c.logger)
+	var channelOpts []ChannelOpt
+	if c.adminIdentity != nil {
+		channelOpts = append(channelOpts, WithAdminIdentity(c.adminIdentity))
+	}
+
+	ch = NewChannel(c.identity.GetMSPIdentifier(), name, c.peerPool, ord, c.discoveryProvider, c.identity, c.fabricV2, c.logger,
+		channelOpts...)
 	c.channels[name] = ch
 	return ch
 }
@@ -147,6 +156,14 @@ func New(identity api.Identity, opts ...CoreOpt) (api.Core, error) {
 		}
 	}
 
+	if core.adminMSP != nil {
+		admin, err := core.adminMSP.Admin()
+		if err != nil {
+			return nil, fmt.Errorf(`msp=%s admin: %w`, core.adminMSP.GetMSPIdentifier(), err)
+		}
+		core.adminIdentity = admin.GetSigningIdentity(core.cs)
+	}
+
 	if core.ctx == nil {
 		core.ctx = context.Background()
 	}
@@ -13,6 +13,7 @@ import (
 	"github.com/vitiko/hlf-sdk-go/api"
 	"github.com/vitiko/hlf-sdk-go/api/config"
 	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/identity"
 )
 
 // CoreOpt describes opt which will be applied to coreOptions
@@ -104,6 +105,15 @@ func WithCrypto(cc config.CryptoConfig) CoreOpt {
 	}
 }
 
+// WithMSPAdmin sets admin identity of MSP for admin-only channel operations (peer join),
+// core identity is used for other requests
+func WithMSPAdmin(mspConfig identity.MSPWithRoles) CoreOpt {
+	return func(c *core) error {
+		c.adminMSP = mspConfig
+		return nil
+	}
+}
+
 // WithFabricV2 toggles core to use fabric version 2.
 func WithFabricV2(fabricV2 bool) CoreOpt {
 	return func(c *core) error {
@@ -34,7 +34,8 @@ func New(deliverClient peer.DeliverClient, identity msp.SigningIdentity, tlsCert
 }
 
 var (
-	_ api.DeliverClient = &Deliver{}
+	_ api.DeliverClient        = &Deliver{}
+	_ api.MultiCCDeliverClient = &Deliver{}
 )
 
 type GetBlockerInfo interface {
@@ -45,6 +46,7 @@ type subscribeEventOption struct {
 	fromTx   string
 	seekOpts []api.EventCCSeekOption
 	qscc     GetBlockerInfo
+	subOpts  []subs.EventSubscriptionOpt
 }
 
 func newEventDefaultOptions() *subscribeEventOption {
@@ -80,6 +82,14 @@ func WithDefaultSeek(seekOpts ...api.EventCCSeekOption) func(*subscribeEventOpti
 	}
 }
 
+// WithEventSubscriptionOpts sets event filters and payload registry, see subs.EventSubscriptionOpt
+func WithEventSubscriptionOpts(subOpts ...subs.EventSubscriptionOpt) func(*subscribeEventOption) error {
+	return func(opt *subscribeEventOption) error {
+		opt.subOpts = append(opt.subOpts, subOpts...)
+		return nil
+	}
+}
+
 func WithGetBlockByTx(seekOpts ...api.EventCCSeekOption) func(*subscribeEventOption) {
 	return func(opt *subscribeEventOption) {
 		if len(seekOpts) > 0 {
@@ -88,9 +98,8 @@ func WithGetBlockByTx(seekOpts ...api.EventCCSeekOption) func(*subscribeEventOpt
 	}
 }
 
-// SubscribeEvents it is just once helper for save to api version today
-func (d *Deliver) SubscribeEvents(ctx context.Context, channelName string, ccName string, setOpts ...func(*subscribeEventOption) error) (api.EventCCSubscription, error) {
-
+// eventOptions applies options, seek starts from block of fromTx if it is set
+func eventOptions(ctx context.Context, channelName string, setOpts ...func(*subscribeEventOption) error) (*subscribeEventOption, error) {
 	options := newEventDefaultOptions()
 
 	for _, setOpt := range setOpts {
@@ -99,8 +108,6 @@ func (d *Deliver) SubscribeEvents(ctx context.Context, channelName string, ccNam
 		}
 	}
 
-	events := subs.NewEventSubscription(ccName, options.fromTx)
-
 	if len(options.fromTx) > 0 {
 		b, err := options.qscc.GetBlockByTxID(ctx, channelName, options.fromTx)
 		if err != nil {
@@ -112,6 +119,18 @@ func (d *Deliver) SubscribeEvents(ctx context.Context, channelName string, ccNam
 		}
 	}
 
+	return options, nil
+}
+
+// SubscribeEvents it is just once helper for save to api version today
+func (d *Deliver) SubscribeEvents(ctx context.Context, channelName string, ccName string, setOpts ...func(*subscribeEventOption) error) (api.EventCCSubscription, error) {
+	options, err := eventOptions(ctx, channelName, setOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := subs.NewEventSubscription(ccName, options.fromTx, options.subOpts...)
+
 	sub, err := d.handleSubscription(ctx, channelName, events.Handler, options.seekOpts...)
 	if err != nil {
 		return nil, err
@@ -131,6 +150,32 @@ func (d *Deliver) SubscribeCC(ctx context.Context, channelName string, ccName st
 	return events.Serve(sub, sub.readyForHandling), nil
 }
 
+func (d *Deliver) SubscribeCCs(ctx context.Context, channelName string, ccNames []string, seekOpt ...api.EventCCSeekOption) (api.EventCCsSubscription, error) {
+	return d.SubscribeChaincodesEvents(ctx, channelName, ccNames, func(opt *subscribeEventOption) error {
+		opt.seekOpts = seekOpt
+		return nil
+	})
+}
+
+// SubscribeChaincodesEvents same as SubscribeCCs, allows to resume after transaction with FromTxID
+// and to set event filters and payload registry with WithEventSubscriptionOpts
+func (d *Deliver) SubscribeChaincodesEvents(ctx context.Context, channelName string, ccNames []string,
+	setOpts ...func(*subscribeEventOption) error) (*subs.ChaincodesEventSubscription, error) {
+	options, err := eventOptions(ctx, channelName, setOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	events := subs.NewChaincodesEventSubscription(ccNames, options.fromTx, options.subOpts...)
+
+	sub, err := d.handleSubscription(ctx, channelName, events.Handler, options.seekOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return events.Serve(sub, sub.readyForHandling), nil
+}
+
 func (d *Deliver) SubscribeTx(ctx context.Context, channelName string, txID string, seekOpt ...api.EventCCSeekOption) (api.TxSubscription, error) {
 	txSub := subs.NewTxSubscription(txID)
 	sub, err := d.handleSubscription(ctx, channelName, txSub.Handler, seekOpt...)
@@ -142,9 +187,23 @@ func (d *Deliver) SubscribeTx(ctx context.Context, channelName string, txID stri
 }
 
 func (d *Deliver) SubscribeBlock(ctx context.Context, channelName string, seekOpt ...api.EventCCSeekOption) (api.BlockSubscription, error) {
-	blocker := subs.NewBlockSubscription()
+	return d.SubscribeBlocks(ctx, channelName, seekOpt)
+}
 
-	sub, err := d.handleSubscription(ctx, channelName, blocker.Handler, seekOpt...)
+// SubscribeBlocks same as SubscribeBlock, allows to enable block chain check and gap refetch
+func (d *Deliver) SubscribeBlocks(ctx context.Context, channelName string,
+	seekOpts []api.EventCCSeekOption, subOpts ...subs.BlockSubscriptionOpt) (*subs.BlockSubscription, error) {
+	// seek start is expected first block for chain check
+	if len(seekOpts) > 0 {
+		if start, _ := seekOpts[0](); start.GetOldest() != nil {
+			subOpts = append([]subs.BlockSubscriptionOpt{subs.WithFirstBlockNumber(0)}, subOpts...)
+		} else if specified := start.GetSpecified(); specified != nil {
+			subOpts = append([]subs.BlockSubscriptionOpt{subs.WithFirstBlockNumber(specified.Number)}, subOpts...)
+		}
+	}
+	blocker := subs.NewBlockSubscription(subOpts...)
+
+	sub, err := d.handleSubscription(ctx, channelName, blocker.Handler, seekOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -152,6 +211,30 @@ func (d *Deliver) SubscribeBlock(ctx context.Context, channelName string, seekOp
 	return blocker.Serve(sub, sub.readyForHandling), nil
 }
 
+// SeekBlockFetcher returns fetcher which gets block by new seek request to deliver service
+func (d *Deliver) SeekBlockFetcher(channelName string) subs.BlockFetcher {
+	return func(ctx context.Context, blockNumber uint64) (*common.Block, error) {
+		sub, err := d.SubscribeBlocks(ctx, channelName, []api.EventCCSeekOption{api.SeekSingle(blockNumber)})
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = sub.Close() }()
+
+		select {
+		case block, ok := <-sub.Blocks():
+			if !ok {
+				if err, hasErr := <-sub.Err(); hasErr && err != nil {
+					return nil, err
+				}
+				return nil, fmt.Errorf(`block=%d: %w`, blockNumber, api.ErrBlockNotFound)
+			}
+			return block, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
 func (d *Deliver) handleSubscription(ctx context.Context, channel string, blockHandler subs.BlockHandler, seekOpt ...api.EventCCSeekOption) (*subscriptionImpl, error) {
 	var startPos, stopPos *orderer.SeekPosition
 	if len(seekOpt) > 0 {
@@ -184,15 +267,16 @@ func (d *Deliver) handleSubscription(ctx context.Context, channel string, blockH
 
 func makeSubscription(ctx context.Context, stop context.CancelFunc, stream peer.Deliver_DeliverClient, blockHandler subs.BlockHandler) *subscriptionImpl {
 	s := &subscriptionImpl{
-		ctx:          ctx,
-		stop:         stop,
-		stream:       stream,
-		blockHandler: blockHandler,
-		once:         new(sync.Once),
-		err:          make(chan error, 1),  // only one error
-		done:         make(chan *struct{}), // done will be closed after finished sub.handle
-		up:           make(chan *struct{}),
-		run:          make(chan *struct{}),
+		ctx:           ctx,
+		stop:          stop,
+		stream:        stream,
+		blockHandler:  blockHandler,
+		once:          new(sync.Once),
+		err:           make(chan error, 1),  // only one error
+		done:          make(chan *struct{}), // done will be closed after finished sub.handle
+		up:            make(chan *struct{}),
+		run:           make(chan *struct{}),
+		rangeComplete: make(chan struct{}),
 	}
 
 	go s.handle()
@@ -211,6 +295,8 @@ type subscriptionImpl struct {
 	done         chan *struct{}
 	up           chan *struct{}
 	run          chan *struct{}
+	// closed when deliver stream returns SUCCESS status after last block of seek range
+	rangeComplete chan struct{}
 }
 
 func (s *subscriptionImpl) handle() {
@@ -245,6 +331,14 @@ func (s *subscriptionImpl) handle() {
 					return
 				}
 			}
+		case *peer.DeliverResponse_Status:
+			if err = api.NewDeliverStatusError(event.Status); err != nil {
+				s.err <- err
+			} else {
+				close(s.rangeComplete)
+			}
+			s.blockHandler(nil)
+			return
 		default:
 			continue
 		}
@@ -263,6 +357,10 @@ func (s *subscriptionImpl) Err() <-chan error {
 	return s.err
 }
 
+func (s *subscriptionImpl) RangeComplete() <-chan struct{} {
+	return s.rangeComplete
+}
+
 func (s *subscriptionImpl) Errors() chan error {
 	return s.err
 }
@@ -0,0 +1,228 @@
+package deliver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"google.golang.org/grpc"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/client/deliver"
+	"github.com/vitiko/hlf-sdk-go/client/deliver/subs"
+	sdktesting "github.com/vitiko/hlf-sdk-go/client/testing"
+	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
+	"github.com/vitiko/hlf-sdk-go/identity"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+// responsesDeliverClient returns predefined responses from deliver stream
+type responsesDeliverClient struct {
+	peer.DeliverClient
+	responses []*peer.DeliverResponse
+}
+
+func (c *responsesDeliverClient) Deliver(ctx context.Context, _ ...grpc.CallOption) (peer.Deliver_DeliverClient, error) {
+	return &responsesStream{ctx: ctx, responses: c.responses}, nil
+}
+
+type responsesStream struct {
+	peer.Deliver_DeliverClient
+	ctx       context.Context
+	responses []*peer.DeliverResponse
+}
+
+func (s *responsesStream) Send(*common.Envelope) error { return nil }
+func (s *responsesStream) CloseSend() error            { return nil }
+func (s *responsesStream) Context() context.Context    { return s.ctx }
+
+func (s *responsesStream) Recv() (*peer.DeliverResponse, error) {
+	if len(s.responses) == 0 {
+		<-s.ctx.Done()
+		return nil, s.ctx.Err()
+	}
+	resp := s.responses[0]
+	s.responses = s.responses[1:]
+	return resp, nil
+}
+
+func newTestDeliver(t *testing.T, responses ...*peer.DeliverResponse) *deliver.Deliver {
+	id, err := identity.SignerFromMSPPath(`Org1MSP`, `../../identity/testdata/Org1MSPPeer`)
+	if err != nil {
+		t.Fatalf("load identity: %s", err)
+	}
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatalf("crypto suite: %s", err)
+	}
+
+	return deliver.New(&responsesDeliverClient{responses: responses}, id.GetSigningIdentity(cs), nil)
+}
+
+func statusResponse(status common.Status) *peer.DeliverResponse {
+	return &peer.DeliverResponse{Type: &peer.DeliverResponse_Status{Status: status}}
+}
+
+func blockResponse(number uint64) *peer.DeliverResponse {
+	return &peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: &common.Block{
+		Header: &common.BlockHeader{Number: number}}}}
+}
+
+func TestSubscriptionStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []*peer.DeliverResponse
+		blocks    int
+		err       error
+	}{
+		{name: `forbidden`, responses: []*peer.DeliverResponse{statusResponse(common.Status_FORBIDDEN)},
+			err: api.ErrDeliverForbidden},
+		{name: `not found`, responses: []*peer.DeliverResponse{statusResponse(common.Status_NOT_FOUND)},
+			err: api.ErrDeliverNotFound},
+		// start block beyond ledger height with FAIL_IF_NOT_READY, peer returns NOT_FOUND before first block
+		{name: `block not found before first block`, responses: []*peer.DeliverResponse{statusResponse(common.Status_NOT_FOUND)},
+			err: api.ErrBlockNotFound},
+		{name: `block not found`, responses: []*peer.DeliverResponse{blockResponse(0), statusResponse(common.Status_NOT_FOUND)},
+			blocks: 1, err: api.ErrBlockNotFound},
+		{name: `range complete`, responses: []*peer.DeliverResponse{blockResponse(0), blockResponse(1), statusResponse(common.Status_SUCCESS)},
+			blocks: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			sub, err := newTestDeliver(t, tc.responses...).SubscribeBlock(ctx, `channel`, api.SeekRange(0, 1))
+			if err != nil {
+				t.Fatalf("subscribe: %s", err)
+			}
+
+			blocks := 0
+			for range sub.Blocks() {
+				blocks++
+			}
+			if blocks != tc.blocks {
+				t.Fatalf("blocks: expected= %d, got= %d", tc.blocks, blocks)
+			}
+
+			err = <-sub.Errors()
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				select {
+				case <-sub.RangeComplete():
+				default:
+					t.Fatal("range complete is not signaled")
+				}
+				return
+			}
+
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error: expected= %s, got= %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestSubscriptionBlockGapRefetch(t *testing.T) {
+	blocks := sdktesting.NewChainedBlocks(4)
+	responses := []*peer.DeliverResponse{
+		{Type: &peer.DeliverResponse_Block{Block: blocks[0]}},
+		// blocks 1 and 2 are missed
+		{Type: &peer.DeliverResponse_Block{Block: blocks[3]}},
+		statusResponse(common.Status_SUCCESS),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	d := newTestDeliver(t, responses...)
+
+	t.Run(`gap without refetch`, func(t *testing.T) {
+		sub, err := d.SubscribeBlocks(ctx, `channel`, []api.EventCCSeekOption{api.SeekRange(0, 3)}, subs.WithBlockChainCheck())
+		if err != nil {
+			t.Fatalf("subscribe: %s", err)
+		}
+		for range sub.Blocks() {
+		}
+		if err = <-sub.Errors(); !errors.Is(err, hlfproto.ErrBlockNumberGap) {
+			t.Fatalf("error: expected= %s, got= %v", hlfproto.ErrBlockNumberGap, err)
+		}
+	})
+
+	t.Run(`gap with refetch`, func(t *testing.T) {
+		sub, err := d.SubscribeBlocks(ctx, `channel`, []api.EventCCSeekOption{api.SeekRange(0, 3)}, subs.WithBlockGapRefetch(
+			func(ctx context.Context, blockNumber uint64) (*common.Block, error) {
+				return blocks[blockNumber], nil
+			}))
+		if err != nil {
+			t.Fatalf("subscribe: %s", err)
+		}
+
+		var expected uint64
+		for b := range sub.Blocks() {
+			if b.Header.Number != expected {
+				t.Fatalf("block: expected= %d, got= %d", expected, b.Header.Number)
+			}
+			expected++
+		}
+		if expected != 4 {
+			t.Fatalf("blocks: expected= 4, got= %d", expected)
+		}
+		if err = <-sub.Errors(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	})
+}
+
+func TestSubscriptionBlockGapAtRangeStart(t *testing.T) {
+	blocks := sdktesting.NewChainedBlocks(4)
+	// stream starts from block 2 instead of requested 1
+	d := newTestDeliver(t,
+		&peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: blocks[2]}},
+		&peer.DeliverResponse{Type: &peer.DeliverResponse_Block{Block: blocks[3]}},
+		statusResponse(common.Status_SUCCESS))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	t.Run(`gap without refetch`, func(t *testing.T) {
+		sub, err := d.SubscribeBlocks(ctx, `channel`, []api.EventCCSeekOption{api.SeekRange(1, 3)}, subs.WithBlockChainCheck())
+		if err != nil {
+			t.Fatalf("subscribe: %s", err)
+		}
+		for b := range sub.Blocks() {
+			t.Fatalf("unexpected block=%d", b.Header.Number)
+		}
+		if err = <-sub.Errors(); !errors.Is(err, hlfproto.ErrBlockNumberGap) {
+			t.Fatalf("error: expected= %s, got= %v", hlfproto.ErrBlockNumberGap, err)
+		}
+	})
+
+	t.Run(`gap with refetch`, func(t *testing.T) {
+		sub, err := d.SubscribeBlocks(ctx, `channel`, []api.EventCCSeekOption{api.SeekRange(1, 3)}, subs.WithBlockGapRefetch(
+			func(ctx context.Context, blockNumber uint64) (*common.Block, error) {
+				return blocks[blockNumber], nil
+			}))
+		if err != nil {
+			t.Fatalf("subscribe: %s", err)
+		}
+
+		expected := uint64(1)
+		for b := range sub.Blocks() {
+			if b.Header.Number != expected {
+				t.Fatalf("block: expected= %d, got= %d", expected, b.Header.Number)
+			}
+			expected++
+		}
+		if expected != 4 {
+			t.Fatalf("last block: expected= 3, got= %d", expected-1)
+		}
+	})
+}
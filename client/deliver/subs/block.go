@@ -1,7 +1,19 @@
 package subs
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
 	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/proto"
+)
+
+var (
+	errSubscriptionDone = errors.New(`subscription done`)
 )
 
 type (
@@ -13,18 +25,63 @@ type (
 		Done() <-chan struct{}
 		Err() <-chan error
 		Errors() chan error
+		RangeComplete() <-chan struct{}
 		Close() error
 	}
+
+	// BlockFetcher returns block by number, used for refetch missing blocks
+	BlockFetcher func(ctx context.Context, blockNumber uint64) (*common.Block, error)
+
+	BlockSubscriptionOpt func(*BlockSubscription)
 )
 
-func NewBlockSubscription() *BlockSubscription {
-	return &BlockSubscription{
+// WithBlockChainCheck enables check that block numbers increase by one
+// and PreviousHash matches hash of prior block header. Subscription fails on first violation
+// with proto.ErrBlockNumberGap or proto.ErrBlockPreviousHashMismatch
+func WithBlockChainCheck() BlockSubscriptionOpt {
+	return func(b *BlockSubscription) {
+		b.checkChain = true
+	}
+}
+
+// WithBlockGapRefetch enables chain check and refetches missing blocks on gap before continuing
+func WithBlockGapRefetch(fetcher BlockFetcher) BlockSubscriptionOpt {
+	return func(b *BlockSubscription) {
+		b.checkChain = true
+		b.refetch = fetcher
+	}
+}
+
+// WithFirstBlockNumber sets number of first expected block, i.e. seek start,
+// with chain check gap before first block is detected (or refetched)
+func WithFirstBlockNumber(number uint64) BlockSubscriptionOpt {
+	return func(b *BlockSubscription) {
+		b.firstNumber = &number
+	}
+}
+
+func NewBlockSubscription(opts ...BlockSubscriptionOpt) *BlockSubscription {
+	b := &BlockSubscription{
 		blocks: make(chan *common.Block, 0),
 	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
 }
 
 type BlockSubscription struct {
 	blocks chan *common.Block
+
+	checkChain bool
+	refetch    BlockFetcher
+	// number of first expected block, nil if unknown
+	firstNumber *uint64
+	// last forwarded block header
+	prevHeader *common.BlockHeader
+
 	ErrorCloser
 }
 
@@ -35,12 +92,90 @@ func (b *BlockSubscription) Blocks() <-chan *common.Block {
 func (b *BlockSubscription) Handler(block *common.Block) bool {
 	if block == nil {
 		close(b.blocks)
-	} else {
-		select {
-		case b.blocks <- block:
-		case <-b.ErrorCloser.Done():
+		return false
+	}
+
+	if !b.checkChain {
+		return b.send(block)
+	}
+
+	if err := b.handleChecked(block); err != nil {
+		if errors.Is(err, errSubscriptionDone) {
 			return true
 		}
+		select {
+		case b.ErrorCloser.Errors() <- err:
+		default:
+		}
+		close(b.blocks)
+		return true
+	}
+
+	return false
+}
+
+// expectedNumber returns number of next block, false if it is unknown (first block of unknown seek start)
+func (b *BlockSubscription) expectedNumber() (uint64, bool) {
+	if b.prevHeader != nil {
+		return b.prevHeader.Number + 1, true
+	}
+	if b.firstNumber != nil {
+		return *b.firstNumber, true
+	}
+	return 0, false
+}
+
+func (b *BlockSubscription) handleChecked(block *common.Block) error {
+	if expected, ok := b.expectedNumber(); ok {
+		switch {
+		// block already forwarded (i.e. refetched before stream delivers it)
+		case block.Header.Number < expected:
+			return nil
+
+		case block.Header.Number > expected:
+			if b.refetch == nil {
+				return fmt.Errorf(`expected block=%d, got=%d: %w`, expected, block.Header.Number, proto.ErrBlockNumberGap)
+			}
+
+			ctx, cancel := contextFromDone(b.ErrorCloser.Done())
+			defer cancel()
+
+			for num := expected; num < block.Header.Number; num++ {
+				missed, err := b.refetch(ctx, num)
+				if err != nil {
+					return fmt.Errorf(`refetch block=%d: %w`, num, err)
+				}
+				if missed.Header.Number != num {
+					return fmt.Errorf(`refetch block=%d, got=%d: %w`, num, missed.Header.Number, proto.ErrBlockNumberGap)
+				}
+				if err = b.checkAndSend(missed); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return b.checkAndSend(block)
+}
+
+func (b *BlockSubscription) checkAndSend(block *common.Block) error {
+	if b.prevHeader != nil && !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHash(b.prevHeader)) {
+		return fmt.Errorf(`block=%d: %w`, block.Header.Number, proto.ErrBlockPreviousHashMismatch)
+	}
+
+	if skip := b.send(block); skip {
+		return errSubscriptionDone
+	}
+
+	b.prevHeader = block.Header
+	return nil
+}
+
+func (b *BlockSubscription) send(block *common.Block) bool {
+	select {
+	case b.blocks <- block:
+	case <-b.ErrorCloser.Done():
+		return true
 	}
 
 	return false
@@ -51,3 +186,17 @@ func (b *BlockSubscription) Serve(base ErrorCloser, readyForHandling ReadyForHan
 	readyForHandling()
 	return b
 }
+
+// contextFromDone returns context cancelled when done is closed
+func contextFromDone(done <-chan struct{}) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, cancel
+}
@@ -5,13 +5,20 @@ import (
 	"github.com/hyperledger/fabric-protos-go/common"
 	"github.com/hyperledger/fabric-protos-go/peer"
 
+	"github.com/vitiko/hlf-sdk-go/api"
 	"github.com/vitiko/hlf-sdk-go/proto"
 )
 
+var _ api.EventPayload = (*ChaincodeEventWithBlock)(nil)
+
 type ChaincodeEventWithBlock struct {
-	event       *peer.ChaincodeEvent
-	block       uint64
-	txTimestamp *timestamp.Timestamp
+	event          *peer.ChaincodeEvent
+	block          uint64
+	txTimestamp    *timestamp.Timestamp
+	validationCode peer.TxValidationCode
+	txIndex        int
+	payload        interface{}
+	payloadErr     error
 }
 
 func (eb *ChaincodeEventWithBlock) Event() *peer.ChaincodeEvent {
@@ -26,10 +33,32 @@ func (eb *ChaincodeEventWithBlock) TxTimestamp() *timestamp.Timestamp {
 	return eb.txTimestamp
 }
 
-func NewEventSubscription(cid string, fromTxID string) *EventSubscription {
+// TxIndex returns position of transaction in block
+func (eb *ChaincodeEventWithBlock) TxIndex() int {
+	return eb.txIndex
+}
+
+// TxValidationCode returns validation code of transaction which emitted event
+func (eb *ChaincodeEventWithBlock) TxValidationCode() peer.TxValidationCode {
+	return eb.validationCode
+}
+
+// Payload returns event payload decoded with proto.EventPayloadRegistry
+// nil if there is no registered type for event name
+func (eb *ChaincodeEventWithBlock) Payload() interface{} {
+	return eb.payload
+}
+
+// PayloadErr returns error occurred while decoding event payload
+func (eb *ChaincodeEventWithBlock) PayloadErr() error {
+	return eb.payloadErr
+}
+
+func NewEventSubscription(cid string, fromTxID string, opts ...EventSubscriptionOpt) *EventSubscription {
 	return &EventSubscription{
 		chaincodeID: cid,
 		fromTx:      fromTxID,
+		opts:        NewEventSubscriptionOpts(opts...),
 		events: make(chan interface {
 			Event() *peer.ChaincodeEvent
 			Block() uint64
@@ -41,6 +70,7 @@ func NewEventSubscription(cid string, fromTxID string) *EventSubscription {
 type EventSubscription struct {
 	chaincodeID string
 	fromTx      string
+	opts        EventSubscriptionOpts
 	events      chan interface {
 		Event() *peer.ChaincodeEvent
 		Block() uint64
@@ -85,12 +115,15 @@ func (e *EventSubscription) Handler(block *common.Block) bool {
 		return true
 	}
 
-	for _, envelope := range parsedBlock.ValidEnvelopes() {
+	for txIndex, envelope := range parsedBlock.Envelopes {
 
 		if envelope.Transaction == nil {
 			continue
 		}
 
+		// fromTx is searched before filters, filtered out fromTx must not block subscription
+		matchEnvelope := e.opts.Filter.MatchEnvelope(envelope)
+
 		for _, ev := range envelope.Transaction.Events() {
 
 			if ev.GetChaincodeId() != e.chaincodeID {
@@ -98,21 +131,19 @@ func (e *EventSubscription) Handler(block *common.Block) bool {
 			}
 
 			if len(e.fromTx) > 0 {
-				if ev.TxId != e.fromTx {
-					continue
-				} else {
+				if ev.TxId == e.fromTx {
 					//reset filter and go to next tx from block
 					e.fromTx = ``
-					continue
 				}
+				continue
+			}
+
+			if !matchEnvelope || !e.opts.Filter.MatchEvent(ev) {
+				continue
 			}
 
 			select {
-			case e.events <- &ChaincodeEventWithBlock{
-				event:       ev,
-				block:       block.Header.Number,
-				txTimestamp: envelope.ChannelHeader.Timestamp,
-			}:
+			case e.events <- e.opts.eventWithBlock(ev, block.Header.Number, txIndex, envelope):
 			case <-e.ErrorCloser.Done():
 				return true
 			}
@@ -122,6 +153,23 @@ func (e *EventSubscription) Handler(block *common.Block) bool {
 	return false
 }
 
+func (o *EventSubscriptionOpts) eventWithBlock(
+	ev *peer.ChaincodeEvent, blockNum uint64, txIndex int, envelope *proto.Envelope) *ChaincodeEventWithBlock {
+	eb := &ChaincodeEventWithBlock{
+		event:          ev,
+		block:          blockNum,
+		txIndex:        txIndex,
+		txTimestamp:    envelope.ChannelHeader.Timestamp,
+		validationCode: envelope.ValidationCode,
+	}
+
+	if o.Payloads != nil {
+		eb.payload, _, eb.payloadErr = o.Payloads.Decode(ev.EventName, ev.Payload)
+	}
+
+	return eb
+}
+
 func (e *EventSubscription) Serve(base ErrorCloser, readyForHandling ReadyForHandling) *EventSubscription {
 	e.ErrorCloser = base
 	readyForHandling()
@@ -0,0 +1,319 @@
+package subs_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/client/deliver/subs"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+type (
+	// testTx - endorser transaction with one chaincode event
+	testTx struct {
+		txID       string
+		creatorMSP string
+		chaincode  string
+		eventName  string
+		payload    []byte
+		code       peer.TxValidationCode
+	}
+
+	// errorCloser is never closed, blocks are passed to handler directly
+	errorCloser struct {
+		subs.ErrorCloser
+		done chan struct{}
+	}
+)
+
+func (c *errorCloser) Done() <-chan struct{} {
+	return c.done
+}
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func txEnvelope(t *testing.T, tx testTx) []byte {
+	signatureHeader := marshal(t, &common.SignatureHeader{
+		Creator: marshal(t, &msp.SerializedIdentity{Mspid: tx.creatorMSP}),
+	})
+
+	chaincodeAction := marshal(t, &peer.ChaincodeAction{
+		ChaincodeId: &peer.ChaincodeID{Name: tx.chaincode},
+		Events: marshal(t, &peer.ChaincodeEvent{
+			ChaincodeId: tx.chaincode,
+			TxId:        tx.txID,
+			EventName:   tx.eventName,
+			Payload:     tx.payload,
+		}),
+	})
+
+	actionPayload := marshal(t, &peer.ChaincodeActionPayload{
+		ChaincodeProposalPayload: marshal(t, &peer.ChaincodeProposalPayload{
+			Input: marshal(t, &peer.ChaincodeInvocationSpec{
+				ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: &peer.ChaincodeID{Name: tx.chaincode}},
+			}),
+		}),
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: marshal(t, &peer.ProposalResponsePayload{Extension: chaincodeAction}),
+		},
+	})
+
+	return marshal(t, &common.Envelope{Payload: marshal(t, &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: marshal(t, &common.ChannelHeader{
+				Type:      int32(common.HeaderType_ENDORSER_TRANSACTION),
+				ChannelId: `channel1`,
+				TxId:      tx.txID,
+			}),
+			SignatureHeader: signatureHeader,
+		},
+		Data: marshal(t, &peer.Transaction{Actions: []*peer.TransactionAction{{
+			Header:  signatureHeader,
+			Payload: actionPayload,
+		}}}),
+	})})
+}
+
+func txBlock(t *testing.T, number uint64, txs ...testTx) *common.Block {
+	block := &common.Block{
+		Header:   &common.BlockHeader{Number: number},
+		Data:     &common.BlockData{},
+		Metadata: &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))},
+	}
+
+	flags := make([]byte, len(txs))
+	for i, tx := range txs {
+		block.Data.Data = append(block.Data.Data, txEnvelope(t, tx))
+		flags[i] = byte(tx.code)
+	}
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+
+	return block
+}
+
+// handle passes blocks to subscription handler and returns received events
+func handle(sub *subs.EventSubscription, blocks ...*common.Block) []interface {
+	Event() *peer.ChaincodeEvent
+	Block() uint64
+	TxTimestamp() *timestamp.Timestamp
+} {
+	sub.Serve(&errorCloser{done: make(chan struct{})}, func() {})
+
+	go func() {
+		for _, block := range blocks {
+			sub.Handler(block)
+		}
+		// nil block closes events channel
+		sub.Handler(nil)
+	}()
+
+	var events []interface {
+		Event() *peer.ChaincodeEvent
+		Block() uint64
+		TxTimestamp() *timestamp.Timestamp
+	}
+	for event := range sub.EventsExtended() {
+		events = append(events, event)
+	}
+
+	return events
+}
+
+func txIDs(events []interface {
+	Event() *peer.ChaincodeEvent
+	Block() uint64
+	TxTimestamp() *timestamp.Timestamp
+}) []string {
+	var ids []string
+	for _, event := range events {
+		ids = append(ids, event.Event().TxId)
+	}
+	return ids
+}
+
+func TestEventSubscription_Filters(t *testing.T) {
+	block := txBlock(t, 1,
+		testTx{txID: `tx1`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `transfer`},
+		testTx{txID: `tx2`, creatorMSP: `Org2MSP`, chaincode: `cc`, eventName: `transfer`},
+		testTx{txID: `tx3`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `mint`},
+		testTx{txID: `tx4`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `transfer`,
+			code: peer.TxValidationCode_MVCC_READ_CONFLICT},
+		testTx{txID: `tx5`, creatorMSP: `Org1MSP`, chaincode: `other`, eventName: `transfer`},
+	)
+
+	for _, c := range []struct {
+		name string
+		opts []subs.EventSubscriptionOpt
+		txs  []string
+	}{
+		{`valid only by default`, nil, []string{`tx1`, `tx2`, `tx3`}},
+		{`event name`, []subs.EventSubscriptionOpt{subs.WithEventName(`transfer`)}, []string{`tx1`, `tx2`}},
+		{`event name prefix`, []subs.EventSubscriptionOpt{subs.WithEventNamePrefix(`mi`)}, []string{`tx3`}},
+		{`creator msp`, []subs.EventSubscriptionOpt{subs.WithCreatorMSP(`Org2MSP`)}, []string{`tx2`}},
+		{`validation code`, []subs.EventSubscriptionOpt{
+			subs.WithValidationCode(peer.TxValidationCode_MVCC_READ_CONFLICT)}, []string{`tx4`}},
+		{`invalid tx`, []subs.EventSubscriptionOpt{subs.WithInvalidTx(), subs.WithEventName(`transfer`)},
+			[]string{`tx1`, `tx2`, `tx4`}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			ids := txIDs(handle(subs.NewEventSubscription(`cc`, ``, c.opts...), block))
+			if len(ids) != len(c.txs) {
+				t.Fatalf("expected= %v, got= %v", c.txs, ids)
+			}
+			for i := range ids {
+				if ids[i] != c.txs[i] {
+					t.Fatalf("expected= %v, got= %v", c.txs, ids)
+				}
+			}
+		})
+	}
+}
+
+func TestEventSubscription_FromTxFiltered(t *testing.T) {
+	blocks := []*common.Block{
+		txBlock(t, 1,
+			testTx{txID: `tx1`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `transfer`},
+			// fromTx is created by msp excluded by filter and is invalid
+			testTx{txID: `tx2`, creatorMSP: `Org2MSP`, chaincode: `cc`, eventName: `transfer`,
+				code: peer.TxValidationCode_MVCC_READ_CONFLICT},
+			testTx{txID: `tx3`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `transfer`},
+		),
+		txBlock(t, 2,
+			testTx{txID: `tx4`, creatorMSP: `Org2MSP`, chaincode: `cc`, eventName: `transfer`},
+			testTx{txID: `tx5`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `transfer`},
+		),
+	}
+
+	events := handle(subs.NewEventSubscription(`cc`, `tx2`, subs.WithCreatorMSP(`Org1MSP`)), blocks...)
+
+	ids := txIDs(events)
+	if len(ids) != 2 || ids[0] != `tx3` || ids[1] != `tx5` {
+		t.Fatalf("expected events after fromTx= [tx3 tx5], got= %v", ids)
+	}
+	if events[1].Block() != 2 {
+		t.Fatalf("expected block= 2, got= %d", events[1].Block())
+	}
+}
+
+func TestEventSubscription_Payload(t *testing.T) {
+	type transfer struct {
+		Amount int `json:"amount"`
+	}
+
+	registry := hlfproto.NewEventPayloadRegistry()
+	if err := registry.Register(`transfer`, &transfer{}); err != nil {
+		t.Fatalf("register: %s", err)
+	}
+
+	payload, err := json.Marshal(transfer{Amount: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := handle(subs.NewEventSubscription(`cc`, ``, subs.WithEventPayloadRegistry(registry)),
+		txBlock(t, 1,
+			testTx{txID: `tx1`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `transfer`, payload: payload},
+			testTx{txID: `tx2`, creatorMSP: `Org1MSP`, chaincode: `cc`, eventName: `unknown`, payload: payload},
+		))
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	payloads := make([]api.EventPayload, len(events))
+	for i, event := range events {
+		withPayload, ok := event.(api.EventPayload)
+		if !ok {
+			t.Fatalf("event %T does not implement api.EventPayload", event)
+		}
+		payloads[i] = withPayload
+	}
+
+	decoded, ok := payloads[0].Payload().(*transfer)
+	if !ok || decoded.Amount != 10 || payloads[0].PayloadErr() != nil {
+		t.Fatalf("unexpected decoded payload: %v, %v", payloads[0].Payload(), payloads[0].PayloadErr())
+	}
+
+	if payloads[1].Payload() != nil {
+		t.Fatalf("payload of unregistered event should be nil")
+	}
+}
+
+func TestChaincodesEventSubscription(t *testing.T) {
+	blocks := []*common.Block{
+		txBlock(t, 1,
+			testTx{txID: `tx1`, creatorMSP: `Org1MSP`, chaincode: `cc1`, eventName: `transfer`},
+			testTx{txID: `tx2`, creatorMSP: `Org1MSP`, chaincode: `cc2`, eventName: `transfer`},
+			testTx{txID: `tx3`, creatorMSP: `Org1MSP`, chaincode: `cc3`, eventName: `transfer`},
+		),
+		txBlock(t, 2,
+			testTx{txID: `tx4`, creatorMSP: `Org1MSP`, chaincode: `cc2`, eventName: `mint`},
+			testTx{txID: `tx5`, creatorMSP: `Org1MSP`, chaincode: `cc1`, eventName: `transfer`},
+		),
+	}
+
+	for _, c := range []struct {
+		name       string
+		chaincodes []string
+		fromTx     string
+		opts       []subs.EventSubscriptionOpt
+		txs        []string
+	}{
+		{`chaincodes list`, []string{`cc1`, `cc2`}, ``, nil, []string{`tx1`, `tx2`, `tx4`, `tx5`}},
+		{`any chaincode`, []string{api.AnyChaincode}, ``, nil, []string{`tx1`, `tx2`, `tx3`, `tx4`, `tx5`}},
+		{`empty list`, nil, ``, []subs.EventSubscriptionOpt{subs.WithEventName(`transfer`)},
+			[]string{`tx1`, `tx2`, `tx3`, `tx5`}},
+		// tx3 is not in chaincodes list, resume point must be found anyway
+		{`from tx`, []string{`cc1`, `cc2`}, `tx3`, nil, []string{`tx4`, `tx5`}},
+		{`from tx filtered out`, nil, `tx4`, []subs.EventSubscriptionOpt{subs.WithEventName(`transfer`)},
+			[]string{`tx5`}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			sub := subs.NewChaincodesEventSubscription(c.chaincodes, c.fromTx, c.opts...)
+			sub.Serve(&errorCloser{done: make(chan struct{})}, func() {})
+
+			go func() {
+				for _, block := range blocks {
+					sub.Handler(block)
+				}
+				sub.Handler(nil)
+			}()
+
+			var (
+				ids       []string
+				positions [][2]uint64
+			)
+			for event := range sub.Events() {
+				ids = append(ids, event.Event().TxId)
+				positions = append(positions, [2]uint64{event.Block(), uint64(event.TxIndex())})
+			}
+
+			if len(ids) != len(c.txs) {
+				t.Fatalf("expected= %v, got= %v", c.txs, ids)
+			}
+			for i := range ids {
+				if ids[i] != c.txs[i] {
+					t.Fatalf("expected= %v, got= %v", c.txs, ids)
+				}
+				// events are ordered by block and tx index
+				if i > 0 && (positions[i][0] < positions[i-1][0] ||
+					positions[i][0] == positions[i-1][0] && positions[i][1] <= positions[i-1][1]) {
+					t.Fatalf("events are not in commit order: %v", positions)
+				}
+			}
+		})
+	}
+}
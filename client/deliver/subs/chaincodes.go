@@ -0,0 +1,119 @@
+package subs
+
+import (
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/proto"
+)
+
+// NewChaincodesEventSubscription creates subscription on events of several chaincodes.
+// Empty list or api.AnyChaincode in list means events of all chaincodes.
+// If fromTxID is set, events of transactions up to fromTxID (inclusive) are skipped
+func NewChaincodesEventSubscription(ccNames []string, fromTxID string, opts ...EventSubscriptionOpt) *ChaincodesEventSubscription {
+	sub := &ChaincodesEventSubscription{
+		chaincodes: make(map[string]struct{}),
+		fromTx:     fromTxID,
+		opts:       NewEventSubscriptionOpts(opts...),
+		events: make(chan interface {
+			Event() *peer.ChaincodeEvent
+			Block() uint64
+			TxIndex() int
+			TxTimestamp() *timestamp.Timestamp
+		}),
+	}
+
+	for _, ccName := range ccNames {
+		if ccName == api.AnyChaincode {
+			sub.anyChaincode = true
+		}
+		sub.chaincodes[ccName] = struct{}{}
+	}
+
+	if len(ccNames) == 0 {
+		sub.anyChaincode = true
+	}
+
+	return sub
+}
+
+type ChaincodesEventSubscription struct {
+	chaincodes   map[string]struct{}
+	anyChaincode bool
+	fromTx       string
+	opts         EventSubscriptionOpts
+	events       chan interface {
+		Event() *peer.ChaincodeEvent
+		Block() uint64
+		TxIndex() int
+		TxTimestamp() *timestamp.Timestamp
+	}
+
+	ErrorCloser
+}
+
+func (e *ChaincodesEventSubscription) Events() chan interface {
+	Event() *peer.ChaincodeEvent
+	Block() uint64
+	TxIndex() int
+	TxTimestamp() *timestamp.Timestamp
+} {
+	return e.events
+}
+
+// Handler sends events in commit order: by block number, then by tx index, then by action position in tx
+func (e *ChaincodesEventSubscription) Handler(block *common.Block) bool {
+	if block == nil {
+		close(e.events)
+		return false
+	}
+
+	parsedBlock, err := proto.ParseBlock(block)
+	if err != nil {
+		return true
+	}
+
+	for txIndex, envelope := range parsedBlock.Envelopes {
+		// fromTx is searched before filters, filtered out fromTx must not block subscription
+		if len(e.fromTx) > 0 {
+			if envelope.ChannelHeader.GetTxId() == e.fromTx {
+				e.fromTx = ``
+			}
+			continue
+		}
+
+		if envelope.Transaction == nil || !e.opts.Filter.MatchEnvelope(envelope) {
+			continue
+		}
+
+		for _, ev := range envelope.Transaction.Events() {
+			if !e.matchChaincode(ev.GetChaincodeId()) || !e.opts.Filter.MatchEvent(ev) {
+				continue
+			}
+
+			select {
+			case e.events <- e.opts.eventWithBlock(ev, block.Header.Number, txIndex, envelope):
+			case <-e.ErrorCloser.Done():
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (e *ChaincodesEventSubscription) matchChaincode(ccName string) bool {
+	if e.anyChaincode {
+		return true
+	}
+	_, ok := e.chaincodes[ccName]
+	return ok
+}
+
+func (e *ChaincodesEventSubscription) Serve(base ErrorCloser, readyForHandling ReadyForHandling) *ChaincodesEventSubscription {
+	e.ErrorCloser = base
+	readyForHandling()
+	return e
+}
@@ -0,0 +1,151 @@
+package subs
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/vitiko/hlf-sdk-go/proto"
+)
+
+type (
+	// EventNameMatcher returns true if chaincode event name satisfies condition
+	EventNameMatcher func(eventName string) bool
+
+	// EventFilter describes which chaincode events are passed to subscriber
+	// empty filter passes all events from valid transactions
+	EventFilter struct {
+		// events passes if name satisfies at least one matcher
+		nameMatchers []EventNameMatcher
+		// events passes if tx creator MSP is in list
+		creatorMSPs map[string]struct{}
+		// events passes if tx validation code is in list, by default only VALID
+		validationCodes map[peer.TxValidationCode]struct{}
+		// events from transactions with any validation code are passed
+		allValidationCodes bool
+	}
+
+	// EventSubscriptionOpts common settings of chaincode event subscriptions
+	EventSubscriptionOpts struct {
+		Filter   EventFilter
+		Payloads *proto.EventPayloadRegistry
+	}
+
+	// EventSubscriptionOpt sets filters and payload registry of single and several chaincodes subscriptions
+	EventSubscriptionOpt func(*EventSubscriptionOpts)
+)
+
+// WithEventName filters events by exact event name
+func WithEventName(names ...string) EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		for _, name := range names {
+			name := name
+			e.Filter.nameMatchers = append(e.Filter.nameMatchers, func(eventName string) bool {
+				return eventName == name
+			})
+		}
+	}
+}
+
+// WithEventNamePrefix filters events by event name prefix
+func WithEventNamePrefix(prefix string) EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		e.Filter.nameMatchers = append(e.Filter.nameMatchers, func(eventName string) bool {
+			return strings.HasPrefix(eventName, prefix)
+		})
+	}
+}
+
+// WithEventNameRegexp filters events by regular expression on event name
+func WithEventNameRegexp(re *regexp.Regexp) EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		e.Filter.nameMatchers = append(e.Filter.nameMatchers, re.MatchString)
+	}
+}
+
+// WithCreatorMSP filters events by MSP identifier of transaction creator
+func WithCreatorMSP(mspIDs ...string) EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		if e.Filter.creatorMSPs == nil {
+			e.Filter.creatorMSPs = make(map[string]struct{})
+		}
+		for _, mspID := range mspIDs {
+			e.Filter.creatorMSPs[mspID] = struct{}{}
+		}
+	}
+}
+
+// WithValidationCode filters events by transaction validation code
+// by default only events from VALID transactions are passed
+func WithValidationCode(codes ...peer.TxValidationCode) EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		if e.Filter.validationCodes == nil {
+			e.Filter.validationCodes = make(map[peer.TxValidationCode]struct{})
+		}
+		for _, code := range codes {
+			e.Filter.validationCodes[code] = struct{}{}
+		}
+	}
+}
+
+// WithInvalidTx passes events from transactions with any validation code
+func WithInvalidTx() EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		e.Filter.allValidationCodes = true
+	}
+}
+
+// WithEventPayloadRegistry sets registry used for decoding event payloads
+func WithEventPayloadRegistry(registry *proto.EventPayloadRegistry) EventSubscriptionOpt {
+	return func(e *EventSubscriptionOpts) {
+		e.Payloads = registry
+	}
+}
+
+func NewEventSubscriptionOpts(opts ...EventSubscriptionOpt) EventSubscriptionOpts {
+	subOpts := EventSubscriptionOpts{}
+	for _, opt := range opts {
+		opt(&subOpts)
+	}
+	return subOpts
+}
+
+// MatchEnvelope checks validation code and creator of transaction
+func (f *EventFilter) MatchEnvelope(envelope *proto.Envelope) bool {
+	if !f.allValidationCodes {
+		if len(f.validationCodes) == 0 {
+			if envelope.ValidationCode != peer.TxValidationCode_VALID {
+				return false
+			}
+		} else if _, ok := f.validationCodes[envelope.ValidationCode]; !ok {
+			return false
+		}
+	}
+
+	if len(f.creatorMSPs) > 0 {
+		if envelope.Transaction == nil {
+			return false
+		}
+		if _, ok := f.creatorMSPs[envelope.Transaction.CreatorIdentity.Mspid]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MatchEvent checks chaincode event name
+func (f *EventFilter) MatchEvent(event *peer.ChaincodeEvent) bool {
+	if len(f.nameMatchers) == 0 {
+		return true
+	}
+
+	for _, match := range f.nameMatchers {
+		if match(event.EventName) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -65,45 +65,16 @@ func OptionsFromConfig(c config.ConnectionConfig, logger *zap.Logger) (*Opts, er
 	}
 
 	if c.Tls.Enabled {
-		var (
-			err    error
-			tlsCfg tls.Config
-		)
-
-		tlsCfg.InsecureSkipVerify = c.Tls.SkipVerify
-		// if custom CA certificate is presented, use it
-		if c.Tls.CACertPath != `` {
-			caCert, err := ioutil.ReadFile(c.Tls.CACertPath)
-			if err != nil {
-				return nil, errors.Wrap(err, `failed to read CA certificate`)
-			}
-			certPool := x509.NewCertPool()
-			if ok := certPool.AppendCertsFromPEM(caCert); !ok {
-				return nil, errors.New(`failed to append CA certificate to chain`)
-			}
-			tlsCfg.RootCAs = certPool
-		} else {
-			// otherwise, we use system certificates
-			if tlsCfg.RootCAs, err = x509.SystemCertPool(); err != nil {
-				return nil, fmt.Errorf(`get system cert pool: %w`, err)
-			}
+		tlsCfg, err := TLSConfig(c.Tls)
+		if err != nil {
+			return nil, err
 		}
-		if c.Tls.CertPath != `` {
-			// use mutual tls if certificate and pk is presented
-			if c.Tls.KeyPath != `` {
-				cert, err := tls.LoadX509KeyPair(c.Tls.CertPath, c.Tls.KeyPath)
-				if err != nil {
-					return nil, fmt.Errorf(`TLS client certificate: %w`, err)
-				}
-				tlsCfg.Certificates = append(tlsCfg.Certificates, cert)
-
-				if len(cert.Certificate) > 0 {
-					opts.TLSCertHash = TLSCertHash(cert.Certificate[0])
-				}
-			}
+
+		if len(tlsCfg.Certificates) > 0 && len(tlsCfg.Certificates[0].Certificate) > 0 {
+			opts.TLSCertHash = TLSCertHash(tlsCfg.Certificates[0].Certificate[0])
 		}
 
-		cred := credentials.NewTLS(&tlsCfg)
+		cred := credentials.NewTLS(tlsCfg)
 		opts.Dial = append(opts.Dial, grpc.WithTransportCredentials(cred))
 	} else {
 		opts.Dial = append(opts.Dial, grpc.WithInsecure())
@@ -158,6 +129,44 @@ func OptionsFromConfig(c config.ConnectionConfig, logger *zap.Logger) (*Opts, er
 	return opts, nil
 }
 
+// TLSConfig creates TLS config with CA certificate (or system cert pool if CA certificate is not set)
+// and client certificate for mutual TLS
+func TLSConfig(c config.TlsConfig) (*tls.Config, error) {
+	var (
+		err    error
+		tlsCfg = &tls.Config{InsecureSkipVerify: c.SkipVerify}
+	)
+
+	// if custom CA certificate is presented, use it
+	if c.CACertPath != `` {
+		caCert, err := ioutil.ReadFile(c.CACertPath)
+		if err != nil {
+			return nil, errors.Wrap(err, `failed to read CA certificate`)
+		}
+		certPool := x509.NewCertPool()
+		if ok := certPool.AppendCertsFromPEM(caCert); !ok {
+			return nil, errors.New(`failed to append CA certificate to chain`)
+		}
+		tlsCfg.RootCAs = certPool
+	} else {
+		// otherwise, we use system certificates
+		if tlsCfg.RootCAs, err = x509.SystemCertPool(); err != nil {
+			return nil, fmt.Errorf(`get system cert pool: %w`, err)
+		}
+	}
+
+	// use mutual tls if certificate and pk is presented
+	if c.CertPath != `` && c.KeyPath != `` {
+		cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf(`TLS client certificate: %w`, err)
+		}
+		tlsCfg.Certificates = append(tlsCfg.Certificates, cert)
+	}
+
+	return tlsCfg, nil
+}
+
 func TLSCertHash(cert []byte) []byte {
 	hash := sha256.Sum256(cert)
 	return hash[:]
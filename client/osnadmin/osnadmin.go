@@ -0,0 +1,252 @@
+// Package osnadmin is client for orderer channel participation API (Fabric 2.3+)
+package osnadmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+
+	"github.com/vitiko/hlf-sdk-go/api/config"
+	"github.com/vitiko/hlf-sdk-go/client/grpc"
+)
+
+const (
+	ChannelsPath = `/participation/v1/channels`
+
+	// ConfigBlockFormField - name of multipart form field with config block for join request
+	ConfigBlockFormField = `config-block`
+)
+
+// Consensus relation of orderer to channel
+const (
+	ConsensusRelationConsenter = `consenter`
+	ConsensusRelationFollower  = `follower`
+	ConsensusRelationConfig    = `config-tracker`
+	ConsensusRelationOther     = `other`
+)
+
+// Channel status on orderer
+const (
+	StatusActive     = `active`
+	StatusOnBoarding = `onboarding`
+	StatusInactive   = `inactive`
+	StatusFailed     = `failed`
+)
+
+var (
+	ErrChannelNotFound = errors.New(`channel not found`)
+	ErrEmptyEndpoint   = errors.New(`empty orderer admin endpoint`)
+)
+
+type (
+	// OrdererAdmin manages channels of orderer with channel participation API, request are authenticated
+	// with mutual TLS, so client TLS certificate must be issued by one of orderer admin TLS CAs
+	OrdererAdmin struct {
+		endpoint string
+		client   *http.Client
+	}
+
+	Opt func(*OrdererAdmin) error
+
+	// ChannelInfoShort - channel name and URL of channel info
+	ChannelInfoShort struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	}
+
+	// ChannelList - channels orderer is member of. System channel is nil if orderer has no system channel
+	ChannelList struct {
+		SystemChannel *ChannelInfoShort  `json:"systemChannel"`
+		Channels      []ChannelInfoShort `json:"channels"`
+	}
+
+	// ChannelInfo - channel status on orderer
+	ChannelInfo struct {
+		Name              string `json:"name"`
+		URL               string `json:"url"`
+		ConsensusRelation string `json:"consensusRelation"`
+		Status            string `json:"status"`
+		Height            uint64 `json:"height"`
+	}
+
+	// ResponseError - error returned by channel participation API
+	ResponseError struct {
+		StatusCode int
+		Message    string `json:"error"`
+	}
+)
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf(`channel participation API status=%d: %s`, e.StatusCode, e.Message)
+}
+
+// Unwrap returns ErrChannelNotFound for 404 status
+func (e *ResponseError) Unwrap() error {
+	if e.StatusCode == http.StatusNotFound {
+		return ErrChannelNotFound
+	}
+	return nil
+}
+
+// WithHTTPClient sets http client, client transport must be configured with mutual TLS
+func WithHTTPClient(client *http.Client) Opt {
+	return func(a *OrdererAdmin) error {
+		a.client = client
+		return nil
+	}
+}
+
+// WithTLS creates http client with mutual TLS from TLS config
+func WithTLS(c config.TlsConfig) Opt {
+	return func(a *OrdererAdmin) error {
+		tlsCfg, err := grpc.TLSConfig(c)
+		if err != nil {
+			return err
+		}
+
+		a.client = &http.Client{Transport: &http.Transport{TLSClientConfig: tlsCfg}}
+		return nil
+	}
+}
+
+// NewOrdererAdmin creates client for orderer admin endpoint, e.g. https://orderer.example.com:7053
+func NewOrdererAdmin(endpoint string, opts ...Opt) (*OrdererAdmin, error) {
+	if endpoint == `` {
+		return nil, ErrEmptyEndpoint
+	}
+
+	a := &OrdererAdmin{
+		endpoint: strings.TrimRight(endpoint, `/`),
+	}
+
+	for _, opt := range opts {
+		if err := opt(a); err != nil {
+			return nil, fmt.Errorf(`apply orderer admin option: %w`, err)
+		}
+	}
+
+	if a.client == nil {
+		a.client = http.DefaultClient
+	}
+
+	return a, nil
+}
+
+// NewOrdererAdminFromConfig creates client for connection config, host is admin endpoint host:port
+func NewOrdererAdminFromConfig(c config.ConnectionConfig) (*OrdererAdmin, error) {
+	if !c.Tls.Enabled {
+		return NewOrdererAdmin(`http://` + c.Host)
+	}
+
+	return NewOrdererAdmin(`https://`+c.Host, WithTLS(c.Tls))
+}
+
+// ListChannels returns channels orderer is member of
+func (a *OrdererAdmin) ListChannels(ctx context.Context) (*ChannelList, error) {
+	list := &ChannelList{}
+	if err := a.do(ctx, http.MethodGet, ChannelsPath, nil, ``, http.StatusOK, list); err != nil {
+		return nil, fmt.Errorf(`list channels: %w`, err)
+	}
+	return list, nil
+}
+
+// ChannelInfo returns status of channel on orderer, returns ErrChannelNotFound if orderer is not member of channel
+func (a *OrdererAdmin) ChannelInfo(ctx context.Context, channel string) (*ChannelInfo, error) {
+	info := &ChannelInfo{}
+	if err := a.do(ctx, http.MethodGet, channelPath(channel), nil, ``, http.StatusOK, info); err != nil {
+		return nil, fmt.Errorf(`channel=%s info: %w`, channel, err)
+	}
+	return info, nil
+}
+
+// Join joins orderer to channel with config block, genesis block or last config block of channel
+func (a *OrdererAdmin) Join(ctx context.Context, configBlock *common.Block) (*ChannelInfo, error) {
+	blockBytes, err := proto.Marshal(configBlock)
+	if err != nil {
+		return nil, fmt.Errorf(`marshal config block: %w`, err)
+	}
+
+	body := &bytes.Buffer{}
+	form := multipart.NewWriter(body)
+	part, err := form.CreateFormFile(ConfigBlockFormField, `config.block`)
+	if err != nil {
+		return nil, fmt.Errorf(`create form: %w`, err)
+	}
+	if _, err = part.Write(blockBytes); err != nil {
+		return nil, fmt.Errorf(`write form: %w`, err)
+	}
+	if err = form.Close(); err != nil {
+		return nil, fmt.Errorf(`close form: %w`, err)
+	}
+
+	info := &ChannelInfo{}
+	if err = a.do(ctx, http.MethodPost, ChannelsPath, body, form.FormDataContentType(), http.StatusCreated, info); err != nil {
+		return nil, fmt.Errorf(`join channel: %w`, err)
+	}
+	return info, nil
+}
+
+// Remove removes orderer from channel, returns ErrChannelNotFound if orderer is not member of channel
+func (a *OrdererAdmin) Remove(ctx context.Context, channel string) error {
+	if err := a.do(ctx, http.MethodDelete, channelPath(channel), nil, ``, http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf(`remove channel=%s: %w`, channel, err)
+	}
+	return nil
+}
+
+func (a *OrdererAdmin) do(
+	ctx context.Context, method, path string, body io.Reader, contentType string, expectedStatus int, out interface{}) error {
+
+	req, err := http.NewRequestWithContext(ctx, method, a.endpoint+path, body)
+	if err != nil {
+		return fmt.Errorf(`create http request: %w`, err)
+	}
+	if contentType != `` {
+		req.Header.Set(`Content-Type`, contentType)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(`http request: %w`, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf(`read response body: %w`, err)
+	}
+
+	if resp.StatusCode != expectedStatus {
+		respErr := &ResponseError{}
+		if err = json.Unmarshal(respBody, respErr); err != nil || respErr.Message == `` {
+			respErr.Message = string(respBody)
+		}
+		respErr.StatusCode = resp.StatusCode
+		return respErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err = json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf(`unmarshal response: %w`, err)
+	}
+
+	return nil
+}
+
+func channelPath(channel string) string {
+	return ChannelsPath + `/` + url.PathEscape(channel)
+}
@@ -0,0 +1,109 @@
+package osnadmin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/client/osnadmin"
+	"github.com/vitiko/hlf-sdk-go/client/osnadmin/osnadmintest"
+)
+
+func configBlock(channel string) *common.Block {
+	block := protoutil.NewBlock(0, nil)
+	block.Data.Data = [][]byte{protoutil.MarshalOrPanic(&common.Envelope{
+		Payload: protoutil.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+				Type:      int32(common.HeaderType_CONFIG),
+				ChannelId: channel,
+			})},
+			Data: protoutil.MarshalOrPanic(&common.ConfigEnvelope{Config: &common.Config{}}),
+		}),
+	})}
+	return block
+}
+
+func TestOrdererAdmin(t *testing.T) {
+	server := osnadmintest.NewTLSServer()
+	defer server.Close()
+
+	admin, err := server.Admin()
+	if err != nil {
+		t.Fatalf("orderer admin: %s", err)
+	}
+
+	ctx := context.Background()
+
+	info, err := admin.Join(ctx, configBlock(`channel1`))
+	if err != nil {
+		t.Fatalf("join: %s", err)
+	}
+	if info.Name != `channel1` || info.Status != osnadmin.StatusActive || info.Height != 1 {
+		t.Fatalf("unexpected joined channel info: %+v", info)
+	}
+
+	_, err = admin.Join(ctx, configBlock(`channel1`))
+	var respErr *osnadmin.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusMethodNotAllowed {
+		t.Fatalf("join twice: expected response error with status 405, got= %v", err)
+	}
+
+	if _, err = admin.Join(ctx, configBlock(`channel2`)); err != nil {
+		t.Fatalf("join: %s", err)
+	}
+
+	list, err := admin.ListChannels(ctx)
+	if err != nil {
+		t.Fatalf("list channels: %s", err)
+	}
+	if list.SystemChannel != nil || len(list.Channels) != 2 || list.Channels[1].Name != `channel2` {
+		t.Fatalf("unexpected channel list: %+v", list)
+	}
+
+	if err = admin.Remove(ctx, `channel1`); err != nil {
+		t.Fatalf("remove: %s", err)
+	}
+
+	if _, err = admin.ChannelInfo(ctx, `channel1`); !errors.Is(err, osnadmin.ErrChannelNotFound) {
+		t.Fatalf("removed channel info: expected= %s, got= %v", osnadmin.ErrChannelNotFound, err)
+	}
+
+	if err = admin.Remove(ctx, `channel1`); !errors.Is(err, osnadmin.ErrChannelNotFound) {
+		t.Fatalf("remove twice: expected= %s, got= %v", osnadmin.ErrChannelNotFound, err)
+	}
+
+	info, err = admin.ChannelInfo(ctx, `channel2`)
+	if err != nil {
+		t.Fatalf("channel info: %s", err)
+	}
+	if info.ConsensusRelation != osnadmin.ConsensusRelationConsenter {
+		t.Fatalf("unexpected channel info: %+v", info)
+	}
+}
+
+func TestOrdererAdmin_SystemChannel(t *testing.T) {
+	server := osnadmintest.NewServer()
+	defer server.Close()
+	server.SetSystemChannel(`system-channel`)
+
+	admin, err := osnadmin.NewOrdererAdmin(server.URL)
+	if err != nil {
+		t.Fatalf("orderer admin: %s", err)
+	}
+
+	list, err := admin.ListChannels(context.Background())
+	if err != nil {
+		t.Fatalf("list channels: %s", err)
+	}
+	if list.SystemChannel == nil || list.SystemChannel.Name != `system-channel` {
+		t.Fatalf("unexpected system channel: %+v", list.SystemChannel)
+	}
+
+	if _, err = admin.Join(context.Background(), configBlock(`channel1`)); err == nil {
+		t.Fatal(`join with system channel: expected error`)
+	}
+}
@@ -0,0 +1,198 @@
+// Package osnadmintest provides in-memory channel participation API server for tests
+package osnadmintest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/client/osnadmin"
+)
+
+type (
+	// Server - httptest server emulating orderer channel participation API
+	Server struct {
+		*httptest.Server
+
+		mu            sync.Mutex
+		channels      map[string]*osnadmin.ChannelInfo
+		systemChannel string
+	}
+)
+
+// NewServer starts plain HTTP server
+func NewServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// NewTLSServer starts HTTPS server, Server.Client is configured to trust server certificate
+func NewTLSServer() *Server {
+	s := newServer()
+	s.Server = httptest.NewTLSServer(s)
+	return s
+}
+
+func newServer() *Server {
+	return &Server{
+		channels: make(map[string]*osnadmin.ChannelInfo),
+	}
+}
+
+// Admin returns client for server
+func (s *Server) Admin() (*osnadmin.OrdererAdmin, error) {
+	return osnadmin.NewOrdererAdmin(s.URL, osnadmin.WithHTTPClient(s.Client()))
+}
+
+// SetSystemChannel emulates orderer with system channel, join is not allowed with system channel
+func (s *Server) SetSystemChannel(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.systemChannel = name
+}
+
+// SetChannel adds or replaces channel info
+func (s *Server) SetChannel(info osnadmin.ChannelInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	info.URL = osnadmin.ChannelsPath + `/` + info.Name
+	s.channels[info.Name] = &info
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.URL.Path, osnadmin.ChannelsPath) {
+		writeError(w, http.StatusNotFound, `path not found`)
+		return
+	}
+
+	channel := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, osnadmin.ChannelsPath), `/`)
+
+	switch {
+	case channel == `` && r.Method == http.MethodGet:
+		s.list(w)
+	case channel == `` && r.Method == http.MethodPost:
+		s.join(w, r)
+	case channel != `` && r.Method == http.MethodGet:
+		s.info(w, channel)
+	case channel != `` && r.Method == http.MethodDelete:
+		s.remove(w, channel)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf(`invalid request method: %s`, r.Method))
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter) {
+	list := osnadmin.ChannelList{Channels: []osnadmin.ChannelInfoShort{}}
+	if s.systemChannel != `` {
+		list.SystemChannel = &osnadmin.ChannelInfoShort{
+			Name: s.systemChannel,
+			URL:  osnadmin.ChannelsPath + `/` + s.systemChannel,
+		}
+	}
+
+	for _, info := range s.channels {
+		list.Channels = append(list.Channels, osnadmin.ChannelInfoShort{Name: info.Name, URL: info.URL})
+	}
+	sort.Slice(list.Channels, func(i, j int) bool {
+		return list.Channels[i].Name < list.Channels[j].Name
+	})
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) info(w http.ResponseWriter, channel string) {
+	info, ok := s.channels[channel]
+	if !ok {
+		writeError(w, http.StatusNotFound, `channel does not exist`)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) join(w http.ResponseWriter, r *http.Request) {
+	if s.systemChannel != `` {
+		writeError(w, http.StatusMethodNotAllowed, `cannot join: system channel exists`)
+		return
+	}
+
+	file, _, err := r.FormFile(osnadmin.ConfigBlockFormField)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(`form file: %s`, err))
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	blockBytes, err := ioutil.ReadAll(file)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(`read config block: %s`, err))
+		return
+	}
+
+	block := &common.Block{}
+	if err = proto.Unmarshal(blockBytes, block); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(`unmarshal config block: %s`, err))
+		return
+	}
+
+	channel, err := protoutil.GetChannelIDFromBlock(block)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(`channel id from block: %s`, err))
+		return
+	}
+
+	if _, ok := s.channels[channel]; ok {
+		writeError(w, http.StatusMethodNotAllowed, `cannot join: channel already exists`)
+		return
+	}
+
+	info := &osnadmin.ChannelInfo{
+		Name:              channel,
+		URL:               osnadmin.ChannelsPath + `/` + channel,
+		ConsensusRelation: osnadmin.ConsensusRelationConsenter,
+		Status:            osnadmin.StatusActive,
+		Height:            block.GetHeader().GetNumber() + 1,
+	}
+	if info.Height > 1 {
+		info.Status = osnadmin.StatusOnBoarding
+	}
+	s.channels[channel] = info
+
+	w.Header().Set(`Location`, info.URL)
+	writeJSON(w, http.StatusCreated, info)
+}
+
+func (s *Server) remove(w http.ResponseWriter, channel string) {
+	if _, ok := s.channels[channel]; !ok {
+		writeError(w, http.StatusNotFound, `channel does not exist`)
+		return
+	}
+
+	delete(s.channels, channel)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set(`Content-Type`, `application/json`)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{`error`: message})
+}
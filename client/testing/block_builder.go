@@ -0,0 +1,138 @@
+package testing
+
+import (
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/util/txflags"
+)
+
+type (
+	// TxWrite - public write of endorser transaction
+	TxWrite struct {
+		Namespace string
+		Key       string
+		Value     []byte
+		IsDelete  bool
+	}
+
+	// Tx - endorser transaction for block building
+	Tx struct {
+		ID             string
+		CreatorMSP     string
+		Chaincode      string
+		Args           [][]byte
+		Writes         []TxWrite
+		Event          *peer.ChaincodeEvent
+		ValidationCode peer.TxValidationCode
+	}
+)
+
+// NewBlock creates unsigned block with endorser transactions, chained to previous block header
+func NewBlock(number uint64, prev *common.BlockHeader, txs ...Tx) *common.Block {
+	var prevHash []byte
+	if prev != nil {
+		prevHash = protoutil.BlockHeaderHash(prev)
+	}
+
+	block := protoutil.NewBlock(number, prevHash)
+	flags := txflags.New(len(txs))
+
+	for i, tx := range txs {
+		block.Data.Data = append(block.Data.Data, protoutil.MarshalOrPanic(NewEndorserTxEnvelope(tx)))
+		flags.SetFlag(i, tx.ValidationCode)
+	}
+
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = flags
+
+	return block
+}
+
+// NewChainedBlocks creates count blocks without transactions from block 0, chained by previous hash
+func NewChainedBlocks(count int) []*common.Block {
+	var blocks []*common.Block
+	for i := 0; i < count; i++ {
+		var prev *common.BlockHeader
+		if i > 0 {
+			prev = blocks[i-1].Header
+		}
+		blocks = append(blocks, NewBlock(uint64(i), prev))
+	}
+	return blocks
+}
+
+// NewEndorserTxEnvelope creates unsigned endorser transaction envelope without endorsements
+func NewEndorserTxEnvelope(tx Tx) *common.Envelope {
+	creator := protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: tx.CreatorMSP})
+	sigHeader := protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: creator})
+
+	// writes grouped by namespace in order of first appearance
+	var (
+		namespaces []string
+		nsWrites   = make(map[string][]*kvrwset.KVWrite)
+	)
+	for _, w := range tx.Writes {
+		if _, ok := nsWrites[w.Namespace]; !ok {
+			namespaces = append(namespaces, w.Namespace)
+		}
+		nsWrites[w.Namespace] = append(nsWrites[w.Namespace], &kvrwset.KVWrite{Key: w.Key, Value: w.Value, IsDelete: w.IsDelete})
+	}
+
+	txRWSet := &rwset.TxReadWriteSet{DataModel: rwset.TxReadWriteSet_KV}
+	for _, ns := range namespaces {
+		txRWSet.NsRwset = append(txRWSet.NsRwset, &rwset.NsReadWriteSet{
+			Namespace: ns,
+			Rwset:     protoutil.MarshalOrPanic(&kvrwset.KVRWSet{Writes: nsWrites[ns]}),
+		})
+	}
+
+	var events []byte
+	if tx.Event != nil {
+		events = protoutil.MarshalOrPanic(tx.Event)
+	}
+
+	ccID := &peer.ChaincodeID{Name: tx.Chaincode}
+	ccAction := &peer.ChaincodeAction{
+		Results:     protoutil.MarshalOrPanic(txRWSet),
+		Events:      events,
+		ChaincodeId: ccID,
+	}
+
+	ccProposalPayload := &peer.ChaincodeProposalPayload{
+		Input: protoutil.MarshalOrPanic(&peer.ChaincodeInvocationSpec{
+			ChaincodeSpec: &peer.ChaincodeSpec{ChaincodeId: ccID, Input: &peer.ChaincodeInput{Args: tx.Args}},
+		}),
+	}
+
+	ccActionPayload := &peer.ChaincodeActionPayload{
+		ChaincodeProposalPayload: protoutil.MarshalOrPanic(ccProposalPayload),
+		Action: &peer.ChaincodeEndorsedAction{
+			ProposalResponsePayload: protoutil.MarshalOrPanic(&peer.ProposalResponsePayload{
+				Extension: protoutil.MarshalOrPanic(ccAction),
+			}),
+		},
+	}
+
+	transaction := &peer.Transaction{Actions: []*peer.TransactionAction{{
+		Header:  sigHeader,
+		Payload: protoutil.MarshalOrPanic(ccActionPayload),
+	}}}
+
+	payload := &common.Payload{
+		Header: &common.Header{
+			ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+				Type: int32(common.HeaderType_ENDORSER_TRANSACTION),
+				TxId: tx.ID,
+			}),
+			SignatureHeader: sigHeader,
+		},
+		Data: protoutil.MarshalOrPanic(transaction),
+	}
+
+	return &common.Envelope{Payload: protoutil.MarshalOrPanic(payload)}
+}
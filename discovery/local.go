@@ -4,14 +4,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/golang/protobuf/proto"
-	"github.com/hyperledger/fabric-protos-go/msp"
-	"github.com/hyperledger/fabric/common/policydsl"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 
 	"github.com/vitiko/hlf-sdk-go/api"
 	"github.com/vitiko/hlf-sdk-go/api/config"
+	"github.com/vitiko/hlf-sdk-go/policy"
 )
 
 // implementation of api.DiscoveryProvider interface
@@ -102,22 +100,11 @@ func (d *LocalConfigProvider) LocalPeers(_ context.Context) (api.LocalPeersDisco
 	return nil, fmt.Errorf("LocalPeers for LocalConfigProvider not implemented")
 }
 
-func getMSPsFromPolicy(policy string) ([]string, error) {
-	policyEnvelope, err := policydsl.FromString(policy)
+func getMSPsFromPolicy(policyDSL string) ([]string, error) {
+	evaluator, err := policy.FromString(policyDSL)
 	if err != nil {
 		return nil, errors.Wrap(err, `failed to parse policy`)
 	}
 
-	mspIds := make([]string, 0)
-
-	for _, id := range policyEnvelope.Identities {
-		var mspIdentity msp.SerializedIdentity
-		if err = proto.Unmarshal(id.Principal, &mspIdentity); err != nil {
-			return nil, errors.Wrap(err, `failed to get MSP identity`)
-		} else {
-			mspIds = append(mspIds, mspIdentity.Mspid)
-		}
-	}
-
-	return mspIds, nil
+	return evaluator.MSPIDs(), nil
 }
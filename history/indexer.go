@@ -0,0 +1,143 @@
+// Package history indexes key modifications from block stream, as peer history database
+package history
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+
+	"github.com/vitiko/hlf-sdk-go/proto"
+	"github.com/vitiko/hlf-sdk-go/util/blockwalk"
+)
+
+var (
+	ErrBlockNumberGap = blockwalk.ErrBlockNumberGap
+)
+
+type (
+	// Indexer records writes and deletes of valid transactions to Storage
+	Indexer struct {
+		storage Storage
+		walker  *blockwalk.Walker
+	}
+
+	IndexerOpt func(*Indexer)
+)
+
+// WithNamespaces limits indexed namespaces (chaincodes), all namespaces are indexed by default
+func WithNamespaces(namespaces ...string) IndexerOpt {
+	return func(i *Indexer) {
+		i.walker.AddNamespaces(namespaces...)
+	}
+}
+
+func NewIndexer(storage Storage, opts ...IndexerOpt) *Indexer {
+	i := &Indexer{
+		storage: storage,
+	}
+	i.walker = blockwalk.New(storage.Height, i.append)
+
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	return i
+}
+
+// Run indexes blocks from stream until stream is closed or context is done
+func (i *Indexer) Run(ctx context.Context, blocks <-chan *common.Block) error {
+	return i.walker.Run(ctx, blocks)
+}
+
+// Apply indexes block. Blocks below storage height are skipped,
+// block above height returns ErrBlockNumberGap
+func (i *Indexer) Apply(block *common.Block) error {
+	return i.walker.Apply(block)
+}
+
+// Height returns number of next block to index
+func (i *Indexer) Height() (uint64, error) {
+	return i.storage.Height()
+}
+
+// History returns writes and deletes of key in commit order
+func (i *Indexer) History(namespace, key string) ([]*Modification, error) {
+	return i.storage.History(namespace, key)
+}
+
+// ValueAt returns last modification of key committed in block less or equal to blockNum.
+// Returns nil if key was not written before or was deleted
+func (i *Indexer) ValueAt(namespace, key string, blockNum uint64) (*Modification, error) {
+	mods, err := i.storage.History(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var last *Modification
+	for _, mod := range mods {
+		if mod.BlockNum > blockNum {
+			break
+		}
+		last = mod
+	}
+
+	if last == nil || last.IsDelete {
+		return nil, nil
+	}
+
+	return last, nil
+}
+
+// ChangedBy returns MSP identifiers of transaction creators changed key, in order of first change
+func (i *Indexer) ChangedBy(namespace, key string) ([]string, error) {
+	mods, err := i.storage.History(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mspIDs []string
+		seen   = make(map[string]struct{})
+	)
+	for _, mod := range mods {
+		if _, ok := seen[mod.CreatorMSP]; ok {
+			continue
+		}
+		seen[mod.CreatorMSP] = struct{}{}
+		mspIDs = append(mspIDs, mod.CreatorMSP)
+	}
+
+	return mspIDs, nil
+}
+
+func txTimestamp(envelope *proto.Envelope) time.Time {
+	if ts := envelope.ChannelHeader.GetTimestamp(); ts != nil {
+		return ts.AsTime()
+	}
+	return time.Time{}
+}
+
+func (i *Indexer) append(blockNum uint64, writes []*blockwalk.Write) error {
+	modifications := make([]*Modification, 0, len(writes))
+	for _, write := range writes {
+		modifications = append(modifications, &Modification{
+			Namespace:  write.Namespace,
+			Key:        write.Key,
+			Value:      write.Value,
+			IsDelete:   write.IsDelete,
+			TxID:       write.Envelope.ChannelHeader.TxId,
+			BlockNum:   write.BlockNum,
+			TxNum:      write.TxNum,
+			Timestamp:  txTimestamp(write.Envelope),
+			CreatorMSP: write.Envelope.Transaction.CreatorIdentity.Mspid,
+		})
+	}
+
+	if err := i.storage.Append(blockNum, modifications); err != nil {
+		return fmt.Errorf(`append block=%d: %w`, blockNum, err)
+	}
+
+	return nil
+}
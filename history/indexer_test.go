@@ -0,0 +1,88 @@
+package history_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	sdktesting "github.com/vitiko/hlf-sdk-go/client/testing"
+	"github.com/vitiko/hlf-sdk-go/history"
+)
+
+func TestIndexerJournaledStorage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), `history.jsonl`)
+
+	b0 := sdktesting.NewBlock(0, nil,
+		sdktesting.Tx{ID: `tx1`, CreatorMSP: `Org1MSP`, ValidationCode: peer.TxValidationCode_VALID,
+			Writes: []sdktesting.TxWrite{{Namespace: `cc`, Key: `a`, Value: []byte(`1`)}}})
+	b1 := sdktesting.NewBlock(1, b0.Header,
+		sdktesting.Tx{ID: `tx2`, CreatorMSP: `Org2MSP`, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+			Writes: []sdktesting.TxWrite{{Namespace: `cc`, Key: `a`, Value: []byte(`invalid`)}}},
+		sdktesting.Tx{ID: `tx3`, CreatorMSP: `Org2MSP`, ValidationCode: peer.TxValidationCode_VALID,
+			Writes: []sdktesting.TxWrite{{Namespace: `cc`, Key: `a`, Value: []byte(`2`)}}})
+	b2 := sdktesting.NewBlock(2, b1.Header,
+		sdktesting.Tx{ID: `tx4`, CreatorMSP: `Org1MSP`, ValidationCode: peer.TxValidationCode_VALID,
+			Writes: []sdktesting.TxWrite{{Namespace: `cc`, Key: `a`, IsDelete: true}}})
+
+	storage, err := history.OpenJournaledStorage(path)
+	if err != nil {
+		t.Fatalf("open storage: %s", err)
+	}
+
+	indexer := history.NewIndexer(storage)
+	if err = indexer.Apply(b0); err != nil {
+		t.Fatalf("apply: %s", err)
+	}
+	if err = indexer.Apply(b1); err != nil {
+		t.Fatalf("apply: %s", err)
+	}
+	if err = storage.Close(); err != nil {
+		t.Fatalf("close: %s", err)
+	}
+
+	// simulate partially written record
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatalf("open file: %s", err)
+	}
+	_, _ = f.WriteString(`{"block_num":2,"modifi`)
+	_ = f.Close()
+
+	if storage, err = history.OpenJournaledStorage(path); err != nil {
+		t.Fatalf("reopen storage: %s", err)
+	}
+	defer func() { _ = storage.Close() }()
+
+	indexer = history.NewIndexer(storage)
+	if height, _ := indexer.Height(); height != 2 {
+		t.Fatalf("height: expected= 2, got= %d", height)
+	}
+	if err = indexer.Apply(b2); err != nil {
+		t.Fatalf("apply: %s", err)
+	}
+
+	mods, err := indexer.History(`cc`, `a`)
+	if err != nil {
+		t.Fatalf("history: %s", err)
+	}
+	if len(mods) != 3 || mods[0].TxID != `tx1` || mods[1].TxID != `tx3` || !mods[2].IsDelete {
+		t.Fatalf("unexpected history: %+v", mods)
+	}
+
+	if mod, _ := indexer.ValueAt(`cc`, `a`, 1); mod == nil || string(mod.Value) != `2` {
+		t.Fatalf("value at block 1: %+v", mod)
+	}
+	if mod, _ := indexer.ValueAt(`cc`, `a`, 2); mod != nil {
+		t.Fatalf("value at block 2 of deleted key: %+v", mod)
+	}
+
+	changedBy, err := indexer.ChangedBy(`cc`, `a`)
+	if err != nil {
+		t.Fatalf("changed by: %s", err)
+	}
+	if len(changedBy) != 2 || changedBy[0] != `Org1MSP` || changedBy[1] != `Org2MSP` {
+		t.Fatalf("changed by: %q", changedBy)
+	}
+}
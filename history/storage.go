@@ -0,0 +1,182 @@
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+type (
+	// Modification - write or delete of key by valid transaction
+	Modification struct {
+		Namespace  string    `json:"namespace"`
+		Key        string    `json:"key"`
+		Value      []byte    `json:"value,omitempty"`
+		IsDelete   bool      `json:"is_delete,omitempty"`
+		TxID       string    `json:"tx_id"`
+		BlockNum   uint64    `json:"block_num"`
+		TxNum      uint64    `json:"tx_num"`
+		Timestamp  time.Time `json:"timestamp"`
+		CreatorMSP string    `json:"creator_msp"`
+	}
+
+	// Storage keeps modifications of keys in commit order
+	Storage interface {
+		// Append adds modifications of block atomically and sets height to blockNum + 1
+		Append(blockNum uint64, modifications []*Modification) error
+		// History returns modifications of key in commit order
+		History(namespace, key string) ([]*Modification, error)
+		// Height returns number of next block to index
+		Height() (uint64, error)
+	}
+
+	MemoryStorage struct {
+		mu      sync.RWMutex
+		height  uint64
+		history map[nsKey][]*Modification
+	}
+
+	// JournaledStorage - MemoryStorage with append-only JSON lines journal, one line per block.
+	// It is not an indexed store: journal is replayed to memory on every open, whole history is kept
+	// in memory and journal is never read after open. Partially written last line is truncated on open
+	JournaledStorage struct {
+		*MemoryStorage
+		mu   sync.Mutex
+		file *os.File
+	}
+
+	nsKey struct {
+		namespace string
+		key       string
+	}
+
+	blockRecord struct {
+		BlockNum      uint64          `json:"block_num"`
+		Modifications []*Modification `json:"modifications"`
+	}
+)
+
+var (
+	_ Storage = (*MemoryStorage)(nil)
+	_ Storage = (*JournaledStorage)(nil)
+)
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		history: make(map[nsKey][]*Modification),
+	}
+}
+
+func (m *MemoryStorage) Append(blockNum uint64, modifications []*Modification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, mod := range modifications {
+		k := nsKey{namespace: mod.Namespace, key: mod.Key}
+		m.history[k] = append(m.history[k], mod)
+	}
+	m.height = blockNum + 1
+
+	return nil
+}
+
+func (m *MemoryStorage) History(namespace, key string) ([]*Modification, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	mods := m.history[nsKey{namespace: namespace, key: key}]
+	return append([]*Modification(nil), mods...), nil
+}
+
+func (m *MemoryStorage) Height() (uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.height, nil
+}
+
+// OpenJournaledStorage opens or creates journal file and replays all its records to memory
+func OpenJournaledStorage(path string) (*JournaledStorage, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf(`open history journal: %w`, err)
+	}
+
+	s := &JournaledStorage{
+		MemoryStorage: NewMemoryStorage(),
+		file:          file,
+	}
+
+	if err = s.replay(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *JournaledStorage) Append(blockNum uint64, modifications []*Modification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := json.Marshal(blockRecord{BlockNum: blockNum, Modifications: modifications})
+	if err != nil {
+		return fmt.Errorf(`marshal block record: %w`, err)
+	}
+
+	if _, err = s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf(`write block record: %w`, err)
+	}
+
+	if err = s.file.Sync(); err != nil {
+		return fmt.Errorf(`sync history journal: %w`, err)
+	}
+
+	return s.MemoryStorage.Append(blockNum, modifications)
+}
+
+func (s *JournaledStorage) Close() error {
+	return s.file.Close()
+}
+
+func (s *JournaledStorage) replay() error {
+	reader := bufio.NewReader(s.file)
+	var offset int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// partially written record without line end
+			break
+		}
+		if err != nil {
+			return fmt.Errorf(`read history journal: %w`, err)
+		}
+
+		var record blockRecord
+		if err = json.Unmarshal(bytes.TrimSpace(line), &record); err != nil {
+			return fmt.Errorf(`unmarshal block record at offset %d: %w`, offset, err)
+		}
+
+		if err = s.MemoryStorage.Append(record.BlockNum, record.Modifications); err != nil {
+			return err
+		}
+		offset += int64(len(line))
+	}
+
+	if err := s.file.Truncate(offset); err != nil {
+		return fmt.Errorf(`truncate history journal: %w`, err)
+	}
+
+	if _, err := s.file.Seek(offset, io.SeekStart); err != nil {
+		return fmt.Errorf(`seek history journal: %w`, err)
+	}
+
+	return nil
+}
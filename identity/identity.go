@@ -14,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/policy"
 )
 
 type (
@@ -22,11 +23,21 @@ type (
 		publicKey   interface{}
 		certificate *x509.Certificate
 		mspId       string
+		verifier    *Verifier
 	}
-	signingIdentity struct {
+	// publicIdentity - msp.Identity without signing, principals and validity are checked with verifier if set
+	publicIdentity struct {
 		identity    *identity
 		cryptoSuite api.CryptoSuite
 	}
+	signingIdentity struct {
+		*publicIdentity
+	}
+)
+
+var (
+	ErrCertificateExpired     = errors.New(`certificate expired`)
+	ErrCertificateNotYetValid = errors.New(`certificate not yet valid`)
 )
 
 func New(mspId string, cert *x509.Certificate, privateKey interface{}) *identity {
@@ -79,10 +90,19 @@ func SignerFromMSPPath(mspId string, mspPath string) (*identity, error) {
 	return FirstFromPath(mspId, SignCertsPath(mspPath), KeystorePath(mspPath))
 }
 
+// WithVerifier returns copy of identity, which is validated and matched to principals with MSP verifier
+func (i *identity) WithVerifier(verifier *Verifier) *identity {
+	withVerifier := *i
+	withVerifier.verifier = verifier
+	return &withVerifier
+}
+
 func (i *identity) GetSigningIdentity(cs api.CryptoSuite) msp.SigningIdentity {
 	return &signingIdentity{
-		identity:    i,
-		cryptoSuite: cs,
+		publicIdentity: &publicIdentity{
+			identity:    i,
+			cryptoSuite: cs,
+		},
 	}
 }
 
@@ -112,39 +132,75 @@ func (i *identity) GetIdentifier() *msp.IdentityIdentifier {
 	}
 }
 
-func (s *signingIdentity) Anonymous() bool {
+func (s *publicIdentity) Anonymous() bool {
 	return false
 }
 
 // ExpiresAt returns date of certificate expiration
-func (s *signingIdentity) ExpiresAt() time.Time {
+func (s *publicIdentity) ExpiresAt() time.Time {
 	return s.identity.certificate.NotAfter
 }
 
-func (s *signingIdentity) GetIdentifier() *msp.IdentityIdentifier {
+func (s *publicIdentity) GetIdentifier() *msp.IdentityIdentifier {
 	return s.identity.GetIdentifier()
 }
 
 // GetMSPIdentifier returns current MspID of identity
-func (s *signingIdentity) GetMSPIdentifier() string {
+func (s *publicIdentity) GetMSPIdentifier() string {
 	return s.identity.GetMSPIdentifier()
 }
 
-func (s *signingIdentity) Validate() error {
-	// TODO
-	return nil
-}
+// Validate checks certificate validity period, then certificate chain, CRLs and OUs with verifier.
+// Validity period is always checked: fabric MSP verifier doesn't check certificate expiration
+func (s *publicIdentity) Validate() error {
+	now := time.Now()
+	if now.After(s.identity.certificate.NotAfter) {
+		return fmt.Errorf(`not after %s: %w`, s.identity.certificate.NotAfter, ErrCertificateExpired)
+	}
+	if now.Before(s.identity.certificate.NotBefore) {
+		return fmt.Errorf(`not before %s: %w`, s.identity.certificate.NotBefore, ErrCertificateNotYetValid)
+	}
+
+	if s.identity.verifier == nil {
+		return nil
+	}
 
-func (s *signingIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
-	// TODO
-	return nil
+	serialized, err := s.Serialize()
+	if err != nil {
+		return err
+	}
+	return s.identity.verifier.Validate(serialized)
+}
+
+// GetOrganizationalUnits returns OUs with certifiers identifiers from verifier,
+// without verifier OUs of certificate subject are returned without certifiers.
+// msp.Identity interface has no error result: nil is returned if identity is not valid for verifier MSP,
+// use Verifier.OrganizationalUnits to get the error
+func (s *publicIdentity) GetOrganizationalUnits() []*msp.OUIdentifier {
+	if s.identity.verifier != nil {
+		serialized, err := s.Serialize()
+		if err != nil {
+			return nil
+		}
+		ous, err := s.identity.verifier.OrganizationalUnits(serialized)
+		if err != nil {
+			return nil
+		}
+		return ous
+	}
+
+	var ous []*msp.OUIdentifier
+	for _, ou := range s.identity.certificate.Subject.OrganizationalUnit {
+		ous = append(ous, &msp.OUIdentifier{OrganizationalUnitIdentifier: ou})
+	}
+	return ous
 }
 
-func (s *signingIdentity) Verify(msg []byte, sig []byte) error {
+func (s *publicIdentity) Verify(msg []byte, sig []byte) error {
 	return s.cryptoSuite.Verify(s.identity.publicKey, msg, sig)
 }
 
-func (s *signingIdentity) Serialize() ([]byte, error) {
+func (s *publicIdentity) Serialize() ([]byte, error) {
 	pb := &pem.Block{Bytes: s.identity.certificate.Raw, Type: "CERTIFICATE"}
 	pemBytes := pem.EncodeToMemory(pb)
 	if pemBytes == nil {
@@ -160,8 +216,23 @@ func (s *signingIdentity) Serialize() ([]byte, error) {
 	return idBytes, nil
 }
 
-func (s *signingIdentity) SatisfiesPrincipal(principal *mspPb.MSPPrincipal) error {
-	panic("implement me")
+// SatisfiesPrincipal checks principal with verifier. Without verifier principals are matched
+// by MSP ID and default NodeOU identifiers of certificate, certificate chain is not checked
+func (s *publicIdentity) SatisfiesPrincipal(principal *mspPb.MSPPrincipal) error {
+	serialized, err := s.Serialize()
+	if err != nil {
+		return err
+	}
+
+	if s.identity.verifier != nil {
+		return s.identity.verifier.SatisfiesPrincipal(serialized, principal)
+	}
+
+	attributes, err := policy.AttributesFromSerialized(serialized)
+	if err != nil {
+		return err
+	}
+	return attributes.SatisfiesPrincipal(principal)
 }
 
 func (s *signingIdentity) Sign(msg []byte) ([]byte, error) {
@@ -169,5 +240,5 @@ func (s *signingIdentity) Sign(msg []byte) ([]byte, error) {
 }
 
 func (s *signingIdentity) GetPublicVersion() msp.Identity {
-	return nil
+	return s.publicIdentity
 }
@@ -1,6 +1,8 @@
 package identity
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -12,8 +14,15 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/policy"
 )
 
+var (
+	ErrAdminNotFound = errors.New(`admin identity not found`)
+)
+
+var _ MSPWithRoles = (*MSPConfig)(nil)
+
 // MSP - contains all parsed identities from msp folder
 // Should be used instead of single `api.Identity` which contains ONLY msp identity
 
@@ -25,6 +34,8 @@ type (
 		admins []api.Identity
 		// identities from 'users'
 		users []api.Identity
+		// identities by NodeOU role, identities from 'admincerts' are admins
+		roles map[mspproto.MSPRole_MSPRoleType][]api.Identity
 
 		mspConfig *mspproto.FabricMSPConfig
 	}
@@ -41,6 +52,13 @@ type (
 		AdminOrSigner() api.Identity
 	}
 
+	// MSPWithRoles - MSP with identities classified by NodeOU role, implemented by MSPConfig
+	MSPWithRoles interface {
+		MSP
+		ByRole(role mspproto.MSPRole_MSPRoleType) []api.Identity
+		Admin() (api.Identity, error)
+	}
+
 	MSPOpts struct {
 		mspPath string
 
@@ -103,6 +121,14 @@ func WithSkipConfig() MSPOpt {
 		mspOpts.skipConfig = true
 	}
 }
+
+// WithValidateCertChain validates loaded identities with MSP verifier built from msp config
+func WithValidateCertChain() MSPOpt {
+	return func(mspOpts *MSPOpts) {
+		mspOpts.validateCertChain = true
+	}
+}
+
 func WithAdminMSPPath(adminMSPPath string) MSPOpt {
 	return func(mspOpts *MSPOpts) {
 		mspOpts.adminMSPPath = adminMSPPath
@@ -188,10 +214,37 @@ func MSPFromPath(mspID, mspPath string, opts ...MSPOpt) (*MSPConfig, error) {
 		}
 	}
 
+	var verifier *Verifier
+	if mspConfig.mspConfig != nil {
+		if verifier, err = mspConfig.Verifier(); err != nil {
+			if mspOpts.validateCertChain {
+				return nil, fmt.Errorf(`msp verifier: %w`, err)
+			}
+			// roles are detected by default NodeOU identifiers
+			logger.Debug(`msp verifier not created`, zap.Error(err))
+		}
+	}
+
+	if verifier != nil {
+		mspConfig.attachVerifier(verifier)
+	}
+
 	if mspOpts.validateCertChain {
-		// todo: validate
+		if err = mspConfig.validateIdentities(verifier); err != nil {
+			return nil, err
+		}
 	}
 
+	if err = mspConfig.classifyIdentities(verifier, logger); err != nil {
+		return nil, err
+	}
+
+	logger.Debug(`identities classified by role`,
+		zap.Int(`admins`, len(mspConfig.roles[mspproto.MSPRole_ADMIN])),
+		zap.Int(`peers`, len(mspConfig.roles[mspproto.MSPRole_PEER])),
+		zap.Int(`clients`, len(mspConfig.roles[mspproto.MSPRole_CLIENT])),
+		zap.Int(`orderers`, len(mspConfig.roles[mspproto.MSPRole_ORDERER])))
+
 	return mspConfig, nil
 }
 
@@ -211,12 +264,29 @@ func (m *MSPConfig) Users() []api.Identity {
 	return m.users
 }
 
+// ByRole returns identities with NodeOU role, admins also include identities from 'admincerts'.
+// All identities are returned for member role
+func (m *MSPConfig) ByRole(role mspproto.MSPRole_MSPRoleType) []api.Identity {
+	return m.roles[role]
+}
+
+// Admin returns identity for admin-only operations (channel join, chaincode install and approve,
+// see client.WithMSPAdmin and deploy.NewOrg): identity from 'admincerts' or admin msp path,
+// otherwise identity with admin NodeOU
+func (m *MSPConfig) Admin() (api.Identity, error) {
+	if admins := m.ByRole(mspproto.MSPRole_ADMIN); len(admins) > 0 {
+		return admins[0], nil
+	}
+
+	return nil, ErrAdminNotFound
+}
+
 // AdminOrSigner - returns admin identity if exists, in another case return msp.
 // installation, fetching  cc list should happen from admin identity
 // if there is admin identity, use it. in another case - try with msp identity
 func (m *MSPConfig) AdminOrSigner() api.Identity {
-	if len(m.admins) != 0 {
-		return m.admins[0]
+	if admin, err := m.Admin(); err == nil {
+		return admin
 	}
 
 	return m.signer
@@ -226,6 +296,128 @@ func (m *MSPConfig) MSPConfig() *mspproto.FabricMSPConfig {
 	return m.mspConfig
 }
 
+// Verifier creates MSP verifier from msp config
+func (m *MSPConfig) Verifier() (*Verifier, error) {
+	return NewVerifierFromFabricConfigs(m.mspConfig)
+}
+
+// identities returns unique admin, signer and user identities
+func (m *MSPConfig) identities() []api.Identity {
+	var identities []api.Identity
+
+	for _, id := range append(append(append([]api.Identity{}, m.admins...), m.signer), m.users...) {
+		if id == nil {
+			continue
+		}
+
+		duplicate := false
+		for _, added := range identities {
+			if bytes.Equal(added.GetPEM(), id.GetPEM()) {
+				duplicate = true
+				break
+			}
+		}
+
+		if !duplicate {
+			identities = append(identities, id)
+		}
+	}
+
+	return identities
+}
+
+// attachVerifier sets MSP verifier to signer, admin and user identities,
+// so they are validated and matched to principals with MSP
+func (m *MSPConfig) attachVerifier(verifier *Verifier) {
+	if m.signer != nil {
+		m.signer = withVerifier(m.signer, verifier)
+	}
+	for i := range m.admins {
+		m.admins[i] = withVerifier(m.admins[i], verifier)
+	}
+	for i := range m.users {
+		m.users[i] = withVerifier(m.users[i], verifier)
+	}
+}
+
+func withVerifier(id api.Identity, verifier *Verifier) api.Identity {
+	if i, ok := id.(*identity); ok {
+		return i.WithVerifier(verifier)
+	}
+	return id
+}
+
+// validateIdentities validates signer, admin and user identities with MSP verifier
+func (m *MSPConfig) validateIdentities(verifier *Verifier) error {
+	if verifier == nil {
+		return ErrMSPConfigMissing
+	}
+
+	for _, id := range m.identities() {
+		serialized, err := serializeIdentity(id)
+		if err != nil {
+			return err
+		}
+
+		if err = verifier.Validate(serialized); err != nil {
+			return fmt.Errorf(`validate identity=%s: %w`, id.GetCert().Subject.CommonName, err)
+		}
+	}
+
+	return nil
+}
+
+// classifyIdentities groups identities by NodeOU role. Role is detected with MSP verifier if msp config is loaded,
+// otherwise by default NodeOU identifiers. Identities not valid for MSP have member role only
+func (m *MSPConfig) classifyIdentities(verifier *Verifier, logger *zap.Logger) error {
+	m.roles = make(map[mspproto.MSPRole_MSPRoleType][]api.Identity)
+
+	for _, id := range m.identities() {
+		role := policy.RoleFromOUs(id.GetCert().Subject.OrganizationalUnit)
+
+		if verifier != nil {
+			serialized, err := serializeIdentity(id)
+			if err != nil {
+				return err
+			}
+
+			if role, err = verifier.Role(serialized); err != nil {
+				logger.Warn(`identity role not detected, member role is used`,
+					zap.String(`identity`, id.GetCert().Subject.CommonName), zap.Error(err))
+				role = mspproto.MSPRole_MEMBER
+			}
+		}
+
+		if isAdmin(m.admins, id) {
+			role = mspproto.MSPRole_ADMIN
+		}
+
+		if role != mspproto.MSPRole_MEMBER {
+			m.roles[role] = append(m.roles[role], id)
+		}
+		m.roles[mspproto.MSPRole_MEMBER] = append(m.roles[mspproto.MSPRole_MEMBER], id)
+	}
+
+	return nil
+}
+
+func isAdmin(admins []api.Identity, id api.Identity) bool {
+	for _, admin := range admins {
+		if bytes.Equal(admin.GetPEM(), id.GetPEM()) {
+			return true
+		}
+	}
+	return false
+}
+
+func serializeIdentity(id api.Identity) ([]byte, error) {
+	serialized, err := proto.Marshal(&mspproto.SerializedIdentity{Mspid: id.GetMSPIdentifier(), IdBytes: id.GetPEM()})
+	if err != nil {
+		return nil, fmt.Errorf(`serialize identity: %w`, err)
+	}
+	return serialized, nil
+}
+
 func (m *MSPConfig) Serialize() (MSPFiles, error) {
 	return SerializeMSP(m.mspConfig)
 }
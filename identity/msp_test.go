@@ -1,8 +1,10 @@
 package identity_test
 
 import (
+	"errors"
 	"testing"
 
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
@@ -119,5 +121,59 @@ var _ = Describe(`Cert`, func() {
 
 		})
 
+		Context(`Identities by NodeOU role`, func() {
+
+			It(`classifies signer by NodeOU and has no admin without admin certs`, func() {
+				msp, err := identity.MSPFromPath(Org1MSPPeer.ID, `testdata/Org1MSPPeer`)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(msp.ByRole(mspproto.MSPRole_PEER)).To(HaveLen(1))
+				Expect(msp.ByRole(mspproto.MSPRole_PEER)[0].GetPEM()).To(Equal(Org1MSPPeer.SignCert))
+				Expect(msp.ByRole(mspproto.MSPRole_MEMBER)).To(HaveLen(1))
+				Expect(msp.ByRole(mspproto.MSPRole_ADMIN)).To(BeEmpty())
+
+				_, err = msp.Admin()
+				Expect(errors.Is(err, identity.ErrAdminNotFound)).To(BeTrue())
+				Expect(msp.AdminOrSigner().GetPEM()).To(Equal(Org1MSPPeer.SignCert))
+			})
+
+			It(`selects admin by NodeOU when msp has no admincerts`, func() {
+				msp, err := identity.MSPFromPath(Org1MSPPeer.ID, `testdata/Org1MSPAdmin`)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(msp.Admins()).To(BeEmpty())
+
+				Expect(msp.ByRole(mspproto.MSPRole_ADMIN)).To(HaveLen(1))
+
+				admin, err := msp.Admin()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admin.GetPEM()).To(Equal(Org1MSPAdmin.SignCert))
+				Expect(msp.AdminOrSigner().GetPEM()).To(Equal(Org1MSPAdmin.SignCert))
+			})
+
+			It(`classifies identities from admin msp path`, func() {
+				msp, err := identity.MSPFromPath(Org1MSPPeer.ID, `testdata/Org1MSPPeer`,
+					identity.WithAdminMSPPath(`testdata/Org1MSPAdmin`))
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(msp.ByRole(mspproto.MSPRole_ADMIN)).To(HaveLen(1))
+				Expect(msp.ByRole(mspproto.MSPRole_PEER)).To(HaveLen(1))
+				Expect(msp.ByRole(mspproto.MSPRole_MEMBER)).To(HaveLen(2))
+				Expect(msp.ByRole(mspproto.MSPRole_CLIENT)).To(BeEmpty())
+
+				admin, err := msp.Admin()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admin.GetPEM()).To(Equal(Org1MSPAdmin.SignCert))
+			})
+
+			It(`detects roles by default NodeOU identifiers without msp config`, func() {
+				msp, err := identity.MSPFromPath(Org1MSPPeer.ID, `testdata/Org1MSPAdmin`, identity.WithSkipConfig())
+				Expect(err).NotTo(HaveOccurred())
+
+				admin, err := msp.Admin()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(admin.GetPEM()).To(Equal(Org1MSPAdmin.SignCert))
+			})
+		})
+
 	})
 })
@@ -0,0 +1,226 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/msp"
+)
+
+var (
+	ErrMSPConfigMissing = errors.New(`msp config missing`)
+	ErrRoleNotFound     = errors.New(`identity role not found`)
+)
+
+type (
+	// Verifier validates identities with fabric MSP implementation: CA and intermediate chain, CRLs,
+	// OU identifiers and NodeOU roles are checked as on peer
+	Verifier struct {
+		manager msp.MSPManager
+	}
+)
+
+var _ msp.IdentityDeserializer = (*Verifier)(nil)
+
+// roles are checked from the most specific, identity satisfies member role if it is valid
+var nodeRoles = []mspproto.MSPRole_MSPRoleType{
+	mspproto.MSPRole_ADMIN,
+	mspproto.MSPRole_PEER,
+	mspproto.MSPRole_CLIENT,
+	mspproto.MSPRole_ORDERER,
+}
+
+// NewVerifier creates verifier for MSP configs
+func NewVerifier(configs ...*mspproto.MSPConfig) (*Verifier, error) {
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, fmt.Errorf(`crypto provider: %w`, err)
+	}
+
+	var msps []msp.MSP
+	for _, config := range configs {
+		opts, ok := msp.Options[msp.ProviderTypeToString(msp.ProviderType(config.Type))]
+		if !ok {
+			return nil, fmt.Errorf(`unknown msp type=%d`, config.Type)
+		}
+
+		m, err := msp.New(opts, cryptoProvider)
+		if err != nil {
+			return nil, fmt.Errorf(`create msp: %w`, err)
+		}
+
+		if err = m.Setup(config); err != nil {
+			return nil, fmt.Errorf(`setup msp: %w`, err)
+		}
+		msps = append(msps, m)
+	}
+
+	manager := msp.NewMSPManager()
+	if err = manager.Setup(msps); err != nil {
+		return nil, fmt.Errorf(`setup msp manager: %w`, err)
+	}
+
+	return &Verifier{manager: manager}, nil
+}
+
+// NewVerifierFromFabricConfigs creates verifier for fabric MSP configs
+func NewVerifierFromFabricConfigs(fabricConfigs ...*mspproto.FabricMSPConfig) (*Verifier, error) {
+	configs := make([]*mspproto.MSPConfig, 0, len(fabricConfigs))
+	for _, fabricConfig := range fabricConfigs {
+		if fabricConfig == nil {
+			return nil, ErrMSPConfigMissing
+		}
+
+		// local msp config contains signing identity without key, verifier doesn't sign
+		verifyingConfig := proto.Clone(fabricConfig).(*mspproto.FabricMSPConfig)
+		verifyingConfig.SigningIdentity = nil
+
+		configBytes, err := proto.Marshal(verifyingConfig)
+		if err != nil {
+			return nil, fmt.Errorf(`marshal msp=%s config: %w`, fabricConfig.Name, err)
+		}
+		configs = append(configs, &mspproto.MSPConfig{Type: int32(msp.FABRIC), Config: configBytes})
+	}
+
+	return NewVerifier(configs...)
+}
+
+// NewVerifierFromMSP creates verifier for MSPs loaded with MSPFromPath or MSPFromConfig
+func NewVerifierFromMSP(msps ...MSP) (*Verifier, error) {
+	fabricConfigs := make([]*mspproto.FabricMSPConfig, 0, len(msps))
+	for _, m := range msps {
+		fabricConfigs = append(fabricConfigs, m.MSPConfig())
+	}
+	return NewVerifierFromFabricConfigs(fabricConfigs...)
+}
+
+// NewVerifierFromChannelConfig creates verifier for MSPs of application and orderer orgs of channel config
+func NewVerifierFromChannelConfig(config *common.Config) (*Verifier, error) {
+	var (
+		configs []*mspproto.MSPConfig
+		mspIDs  = make(map[string]struct{})
+	)
+
+	for _, groupKey := range []string{channelconfig.ApplicationGroupKey, channelconfig.OrdererGroupKey} {
+		for org, orgGroup := range config.GetChannelGroup().GetGroups()[groupKey].GetGroups() {
+			value, ok := orgGroup.Values[channelconfig.MSPKey]
+			if !ok {
+				return nil, fmt.Errorf(`org=%s: %w`, org, ErrMSPConfigMissing)
+			}
+
+			mspConfig := &mspproto.MSPConfig{}
+			if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+				return nil, fmt.Errorf(`org=%s: unmarshal msp config: %w`, org, err)
+			}
+
+			// the same org can be in application and orderer groups
+			mspID, err := mspConfigName(mspConfig)
+			if err != nil {
+				return nil, fmt.Errorf(`org=%s: %w`, org, err)
+			}
+			if _, ok = mspIDs[mspID]; ok {
+				continue
+			}
+			mspIDs[mspID] = struct{}{}
+
+			configs = append(configs, mspConfig)
+		}
+	}
+
+	return NewVerifier(configs...)
+}
+
+// DeserializeIdentity returns identity backed by MSP, verifier implements msp.IdentityDeserializer
+func (v *Verifier) DeserializeIdentity(serialized []byte) (msp.Identity, error) {
+	return v.manager.DeserializeIdentity(serialized)
+}
+
+// IsWellFormed checks that identity has well formed certificate
+func (v *Verifier) IsWellFormed(identity *mspproto.SerializedIdentity) error {
+	return v.manager.IsWellFormed(identity)
+}
+
+// Validate checks identity certificate chain, CRLs and OUs, certificate expiration is not checked
+func (v *Verifier) Validate(serialized []byte) error {
+	id, err := v.DeserializeIdentity(serialized)
+	if err != nil {
+		return fmt.Errorf(`deserialize identity: %w`, err)
+	}
+	return id.Validate()
+}
+
+// SatisfiesPrincipal checks that identity is valid and satisfies principal
+func (v *Verifier) SatisfiesPrincipal(serialized []byte, principal *mspproto.MSPPrincipal) error {
+	id, err := v.DeserializeIdentity(serialized)
+	if err != nil {
+		return fmt.Errorf(`deserialize identity: %w`, err)
+	}
+	return id.SatisfiesPrincipal(principal)
+}
+
+// OrganizationalUnits returns OUs of identity with certifiers identifiers
+func (v *Verifier) OrganizationalUnits(serialized []byte) ([]*msp.OUIdentifier, error) {
+	id, err := v.DeserializeIdentity(serialized)
+	if err != nil {
+		return nil, fmt.Errorf(`deserialize identity: %w`, err)
+	}
+	return id.GetOrganizationalUnits(), nil
+}
+
+// Role returns NodeOU role of identity, admin role is also detected by admin certs. Member is returned
+// for valid identity without node role
+func (v *Verifier) Role(serialized []byte) (mspproto.MSPRole_MSPRoleType, error) {
+	id, err := v.DeserializeIdentity(serialized)
+	if err != nil {
+		return 0, fmt.Errorf(`deserialize identity: %w`, err)
+	}
+
+	for _, role := range nodeRoles {
+		principal, err := rolePrincipal(id.GetMSPIdentifier(), role)
+		if err != nil {
+			return 0, err
+		}
+		if id.SatisfiesPrincipal(principal) == nil {
+			return role, nil
+		}
+	}
+
+	if err = id.Validate(); err != nil {
+		return 0, fmt.Errorf(`%w: %s`, ErrRoleNotFound, err)
+	}
+
+	return mspproto.MSPRole_MEMBER, nil
+}
+
+func rolePrincipal(mspID string, role mspproto.MSPRole_MSPRoleType) (*mspproto.MSPPrincipal, error) {
+	roleBytes, err := proto.Marshal(&mspproto.MSPRole{MspIdentifier: mspID, Role: role})
+	if err != nil {
+		return nil, fmt.Errorf(`marshal msp role: %w`, err)
+	}
+
+	return &mspproto.MSPPrincipal{
+		PrincipalClassification: mspproto.MSPPrincipal_ROLE,
+		Principal:               roleBytes,
+	}, nil
+}
+
+func mspConfigName(config *mspproto.MSPConfig) (string, error) {
+	if msp.ProviderType(config.Type) == msp.IDEMIX {
+		idemixConfig := &mspproto.IdemixMSPConfig{}
+		if err := proto.Unmarshal(config.Config, idemixConfig); err != nil {
+			return ``, fmt.Errorf(`unmarshal idemix msp config: %w`, err)
+		}
+		return idemixConfig.Name, nil
+	}
+
+	fabricConfig := &mspproto.FabricMSPConfig{}
+	if err := proto.Unmarshal(config.Config, fabricConfig); err != nil {
+		return ``, fmt.Errorf(`unmarshal fabric msp config: %w`, err)
+	}
+	return fabricConfig.Name, nil
+}
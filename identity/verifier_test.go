@@ -0,0 +1,134 @@
+package identity_test
+
+import (
+	"errors"
+
+	"github.com/golang/protobuf/proto"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vitiko/hlf-sdk-go/api"
+	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
+	"github.com/vitiko/hlf-sdk-go/identity"
+	"github.com/vitiko/hlf-sdk-go/identity/testdata/Org1MSPAdmin"
+	"github.com/vitiko/hlf-sdk-go/identity/testdata/Org1MSPPeer"
+)
+
+func rolePrincipal(mspID string, role mspproto.MSPRole_MSPRoleType) *mspproto.MSPPrincipal {
+	roleBytes, err := proto.Marshal(&mspproto.MSPRole{MspIdentifier: mspID, Role: role})
+	Expect(err).NotTo(HaveOccurred())
+
+	return &mspproto.MSPPrincipal{PrincipalClassification: mspproto.MSPPrincipal_ROLE, Principal: roleBytes}
+}
+
+func serialized(mspID string, cert []byte) []byte {
+	sid, err := proto.Marshal(&mspproto.SerializedIdentity{Mspid: mspID, IdBytes: cert})
+	Expect(err).NotTo(HaveOccurred())
+	return sid
+}
+
+var _ = Describe(`Verifier`, func() {
+
+	var (
+		verifier *identity.Verifier
+		cs       api.CryptoSuite
+		err      error
+	)
+
+	BeforeEach(func() {
+		verifier, err = identity.NewVerifierFromFabricConfigs(Org1MSPPeer.FabricMSPConfig())
+		Expect(err).NotTo(HaveOccurred())
+
+		cs, err = crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It(`classifies identities by NodeOU role`, func() {
+		role, err := verifier.Role(serialized(Org1MSPPeer.ID, Org1MSPPeer.SignCert))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(role).To(Equal(mspproto.MSPRole_PEER))
+
+		role, err = verifier.Role(serialized(Org1MSPPeer.ID, Org1MSPAdmin.SignCert))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(role).To(Equal(mspproto.MSPRole_ADMIN))
+	})
+
+	It(`returns OUs with certifiers identifiers`, func() {
+		ous, err := verifier.OrganizationalUnits(serialized(Org1MSPPeer.ID, Org1MSPPeer.SignCert))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ous).To(HaveLen(1))
+		Expect(ous[0].OrganizationalUnitIdentifier).To(Equal(`peer`))
+		Expect(ous[0].CertifiersIdentifier).NotTo(BeEmpty())
+	})
+
+	It(`rejects identity of unknown msp`, func() {
+		Expect(verifier.Validate(serialized(`Org2MSP`, Org1MSPPeer.SignCert))).To(HaveOccurred())
+	})
+
+	It(`allows to use SDK identity with fabric policy code`, func() {
+		signer, err := identity.SignerFromMSPPath(Org1MSPPeer.ID, `testdata/Org1MSPPeer`)
+		Expect(err).NotTo(HaveOccurred())
+
+		signingIdentity := signer.WithVerifier(verifier).GetSigningIdentity(cs)
+		// testdata certificates are expired, validity period is checked with verifier too
+		Expect(errors.Is(signingIdentity.Validate(), identity.ErrCertificateExpired)).To(BeTrue())
+		Expect(signingIdentity.SatisfiesPrincipal(rolePrincipal(Org1MSPPeer.ID, mspproto.MSPRole_PEER))).NotTo(HaveOccurred())
+		Expect(signingIdentity.SatisfiesPrincipal(rolePrincipal(Org1MSPPeer.ID, mspproto.MSPRole_MEMBER))).NotTo(HaveOccurred())
+		Expect(signingIdentity.SatisfiesPrincipal(rolePrincipal(Org1MSPPeer.ID, mspproto.MSPRole_ADMIN))).To(HaveOccurred())
+
+		ous := signingIdentity.GetOrganizationalUnits()
+		Expect(ous).To(HaveLen(1))
+		Expect(ous[0].CertifiersIdentifier).NotTo(BeEmpty())
+
+		Expect(signingIdentity.GetPublicVersion()).NotTo(BeNil())
+		Expect(signingIdentity.GetPublicVersion().GetMSPIdentifier()).To(Equal(Org1MSPPeer.ID))
+	})
+
+	It(`matches principals by certificate without verifier`, func() {
+		signer, err := identity.SignerFromMSPPath(Org1MSPPeer.ID, `testdata/Org1MSPAdmin`)
+		Expect(err).NotTo(HaveOccurred())
+
+		signingIdentity := signer.GetSigningIdentity(cs)
+		Expect(signingIdentity.SatisfiesPrincipal(rolePrincipal(Org1MSPPeer.ID, mspproto.MSPRole_ADMIN))).NotTo(HaveOccurred())
+		Expect(signingIdentity.SatisfiesPrincipal(rolePrincipal(Org1MSPPeer.ID, mspproto.MSPRole_PEER))).To(HaveOccurred())
+
+		ous := signingIdentity.GetOrganizationalUnits()
+		Expect(ous).To(HaveLen(1))
+		Expect(ous[0].OrganizationalUnitIdentifier).To(Equal(`admin`))
+
+		// testdata certificates are expired
+		Expect(errors.Is(signingIdentity.Validate(), identity.ErrCertificateExpired)).To(BeTrue())
+	})
+
+	It(`validates identities loaded from msp path`, func() {
+		msp, err := identity.MSPFromPath(Org1MSPPeer.ID, `testdata/Org1MSPPeer`,
+			identity.WithAdminMSPPath(`testdata/Org1MSPAdmin`), identity.WithValidateCertChain())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(msp.Admins()).To(HaveLen(1))
+	})
+
+	It(`attaches verifier to identities loaded from msp path`, func() {
+		msp, err := identity.MSPFromPath(Org1MSPPeer.ID, `testdata/Org1MSPPeer`,
+			identity.WithAdminMSPPath(`testdata/Org1MSPAdmin`))
+		Expect(err).NotTo(HaveOccurred())
+
+		signer := msp.Signer().GetSigningIdentity(cs)
+		// testdata certificates are expired, verifier checks only certificate chain
+		Expect(errors.Is(signer.Validate(), identity.ErrCertificateExpired)).To(BeTrue())
+		signerSerialized, err := signer.Serialize()
+		Expect(err).NotTo(HaveOccurred())
+		verifier, err := identity.NewVerifierFromMSP(msp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(verifier.Validate(signerSerialized)).NotTo(HaveOccurred())
+		ous := signer.GetOrganizationalUnits()
+		Expect(ous).To(HaveLen(1))
+		Expect(ous[0].CertifiersIdentifier).NotTo(BeEmpty())
+
+		Expect(msp.Admins()).To(HaveLen(1))
+		admin := msp.Admins()[0].GetSigningIdentity(cs)
+		Expect(admin.SatisfiesPrincipal(rolePrincipal(Org1MSPPeer.ID, mspproto.MSPRole_ADMIN))).NotTo(HaveOccurred())
+		Expect(admin.GetOrganizationalUnits()[0].CertifiersIdentifier).NotTo(BeEmpty())
+	})
+})
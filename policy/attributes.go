@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+)
+
+type (
+	// Attributes - identity described by MSP ID, NodeOU role and organizational units.
+	// Principals are matched without MSP: certificate chain and OU certifiers are not checked
+	Attributes struct {
+		MSPID string
+		// Role - admin, peer, client or orderer if identity has NodeOU, member otherwise
+		Role mspproto.MSPRole_MSPRoleType
+		OUs  []string
+		// Serialized identity, used for identity principals
+		Serialized []byte
+	}
+)
+
+var _ Identity = (*Attributes)(nil)
+
+// AttributesFromSerialized returns attributes of serialized identity, role is read from default NodeOU identifiers
+func AttributesFromSerialized(serialized []byte) (*Attributes, error) {
+	sid := &mspproto.SerializedIdentity{}
+	if err := proto.Unmarshal(serialized, sid); err != nil {
+		return nil, fmt.Errorf(`unmarshal serialized identity: %w`, err)
+	}
+
+	cert, err := certificate(sid.IdBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Attributes{
+		MSPID:      sid.Mspid,
+		Role:       RoleFromOUs(cert.Subject.OrganizationalUnit),
+		OUs:        cert.Subject.OrganizationalUnit,
+		Serialized: serialized,
+	}, nil
+}
+
+// RoleFromOUs returns role by default NodeOU identifiers, member if no NodeOU found
+func RoleFromOUs(ous []string) mspproto.MSPRole_MSPRoleType {
+	for _, ou := range ous {
+		switch ou {
+		case AdminOU:
+			return mspproto.MSPRole_ADMIN
+		case PeerOU:
+			return mspproto.MSPRole_PEER
+		case ClientOU:
+			return mspproto.MSPRole_CLIENT
+		case OrdererOU:
+			return mspproto.MSPRole_ORDERER
+		}
+	}
+	return mspproto.MSPRole_MEMBER
+}
+
+// SatisfiesPrincipal checks role, OU and identity principals. Any identity of MSP satisfies member role
+func (a *Attributes) SatisfiesPrincipal(principal *mspproto.MSPPrincipal) error {
+	switch principal.PrincipalClassification {
+	case mspproto.MSPPrincipal_ROLE:
+		role := &mspproto.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return fmt.Errorf(`unmarshal msp role: %w`, err)
+		}
+		if role.MspIdentifier == a.MSPID && (role.Role == mspproto.MSPRole_MEMBER || role.Role == a.Role) {
+			return nil
+		}
+		return fmt.Errorf(`role=%s.%s: %w`, role.MspIdentifier, role.Role, ErrPrincipalNotSatisfied)
+
+	case mspproto.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mspproto.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return fmt.Errorf(`unmarshal organization unit: %w`, err)
+		}
+		if ou.MspIdentifier == a.MSPID && containsString(a.OUs, ou.OrganizationalUnitIdentifier) {
+			return nil
+		}
+		return fmt.Errorf(`ou=%s.%s: %w`, ou.MspIdentifier, ou.OrganizationalUnitIdentifier, ErrPrincipalNotSatisfied)
+
+	case mspproto.MSPPrincipal_IDENTITY:
+		if len(a.Serialized) > 0 && bytes.Equal(a.Serialized, principal.Principal) {
+			return nil
+		}
+		return fmt.Errorf(`identity: %w`, ErrPrincipalNotSatisfied)
+	}
+
+	return fmt.Errorf(`%s: %w`, principal.PrincipalClassification, ErrPrincipalUnsupported)
+}
@@ -0,0 +1,441 @@
+// Package policy evaluates signature policies against identities and endorsements
+// and lists minimal combinations of principals satisfying policy
+package policy
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+)
+
+// Default NodeOU identifiers, used by fabric-ca and cryptogen
+const (
+	ClientOU  = `client`
+	PeerOU    = `peer`
+	AdminOU   = `admin`
+	OrdererOU = `orderer`
+)
+
+var (
+	ErrPolicyNotSatisfied    = errors.New(`signature policy not satisfied`)
+	ErrPolicyEmpty           = errors.New(`signature policy rule is empty`)
+	ErrPrincipalOutOfRange   = errors.New(`principal index out of range`)
+	ErrPrincipalNotSatisfied = errors.New(`principal not satisfied`)
+	ErrPrincipalUnsupported  = errors.New(`principal classification not supported`)
+)
+
+type (
+	// Identity satisfies principals, implemented by fabric msp.Identity and Attributes
+	Identity interface {
+		SatisfiesPrincipal(principal *mspproto.MSPPrincipal) error
+	}
+
+	// Combination - principals each satisfied by distinct identity
+	Combination []*mspproto.MSPPrincipal
+
+	// Evaluator evaluates signature policy the same way as peer does
+	Evaluator struct {
+		envelope *common.SignaturePolicyEnvelope
+	}
+
+	// combination - counts of principal indexes
+	combination map[int32]int
+)
+
+// New creates evaluator of signature policy
+func New(envelope *common.SignaturePolicyEnvelope) (*Evaluator, error) {
+	if err := validateRule(envelope.GetRule(), len(envelope.GetIdentities())); err != nil {
+		return nil, err
+	}
+
+	return &Evaluator{envelope: envelope}, nil
+}
+
+// FromString creates evaluator of signature policy DSL, e.g. "OR('Org1MSP.peer', 'Org2MSP.peer')"
+func FromString(dsl string) (*Evaluator, error) {
+	envelope, err := policydsl.FromString(dsl)
+	if err != nil {
+		return nil, fmt.Errorf(`parse policy=%s: %w`, dsl, err)
+	}
+	return New(envelope)
+}
+
+// Envelope returns evaluated signature policy
+func (e *Evaluator) Envelope() *common.SignaturePolicyEnvelope {
+	return e.envelope
+}
+
+// Principals returns principals of policy
+func (e *Evaluator) Principals() []*mspproto.MSPPrincipal {
+	return e.envelope.Identities
+}
+
+// MSPIDs returns MSP IDs of role, OU and identity principals of policy
+func (e *Evaluator) MSPIDs() []string {
+	return Combination(e.envelope.Identities).MSPIDs()
+}
+
+// Evaluate returns nil if identities satisfy policy. As on peer, each identity satisfies one principal only
+// and rules are evaluated in order without backtracking
+func (e *Evaluator) Evaluate(identities ...Identity) error {
+	used := make([]bool, len(identities))
+	if !e.evaluate(e.envelope.Rule, identities, used) {
+		return ErrPolicyNotSatisfied
+	}
+	return nil
+}
+
+// EvaluateEndorsements checks that endorsers satisfy policy, endorsers are matched with Attributes of certificate.
+// Endorsement signatures are not verified, duplicated endorsers are counted once
+func (e *Evaluator) EvaluateEndorsements(endorsements []*peer.Endorsement) error {
+	var (
+		identities []Identity
+		seen       [][]byte
+	)
+
+	for _, endorsement := range endorsements {
+		if containsBytes(seen, endorsement.Endorser) {
+			continue
+		}
+		seen = append(seen, endorsement.Endorser)
+
+		attributes, err := AttributesFromSerialized(endorsement.Endorser)
+		if err != nil {
+			return fmt.Errorf(`endorser: %w`, err)
+		}
+		identities = append(identities, attributes)
+	}
+
+	return e.Evaluate(identities...)
+}
+
+// Combinations returns all minimal combinations of principals satisfying policy.
+// Combination is not minimal if other combination requires the same or weaker principals,
+// e.g. 'Org1MSP.member' is weaker than 'Org1MSP.peer'
+func (e *Evaluator) Combinations() []Combination {
+	minimal := minimize(combinations(e.envelope.Rule), e.envelope.Identities)
+	result := make([]Combination, 0, len(minimal))
+	for _, c := range minimal {
+		result = append(result, c.principals(e.envelope.Identities))
+	}
+
+	return result
+}
+
+// MSPIDs returns unique MSP IDs of principals of combination
+func (c Combination) MSPIDs() []string {
+	var mspIDs []string
+	for _, principal := range c {
+		if mspID, ok := principalMSPID(principal); ok && !containsString(mspIDs, mspID) {
+			mspIDs = append(mspIDs, mspID)
+		}
+	}
+	return mspIDs
+}
+
+func (e *Evaluator) evaluate(rule *common.SignaturePolicy, identities []Identity, used []bool) bool {
+	switch r := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		principal := e.envelope.Identities[r.SignedBy]
+		for i, identity := range identities {
+			if used[i] {
+				continue
+			}
+			if identity.SatisfiesPrincipal(principal) == nil {
+				used[i] = true
+				return true
+			}
+		}
+		return false
+
+	case *common.SignaturePolicy_NOutOf_:
+		var verified int32
+		ruleUsed := make([]bool, len(used))
+		for _, subRule := range r.NOutOf.GetRules() {
+			copy(ruleUsed, used)
+			if e.evaluate(subRule, identities, ruleUsed) {
+				verified++
+				copy(used, ruleUsed)
+			}
+		}
+		return verified >= r.NOutOf.GetN()
+	}
+
+	return false
+}
+
+func validateRule(rule *common.SignaturePolicy, principalsCount int) error {
+	switch r := rule.GetType().(type) {
+	case *common.SignaturePolicy_SignedBy:
+		if r.SignedBy < 0 || int(r.SignedBy) >= principalsCount {
+			return fmt.Errorf(`index=%d, principals=%d: %w`, r.SignedBy, principalsCount, ErrPrincipalOutOfRange)
+		}
+		return nil
+
+	case *common.SignaturePolicy_NOutOf_:
+		if r.NOutOf == nil {
+			return fmt.Errorf(`n out of: %w`, ErrPolicyEmpty)
+		}
+		for _, subRule := range r.NOutOf.Rules {
+			if err := validateRule(subRule, principalsCount); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return ErrPolicyEmpty
+}
+
+// combinations returns all combinations of principals satisfying rule, not minimized
+func combinations(rule *common.SignaturePolicy) []combination {
+	switch r := rule.Type.(type) {
+	case *common.SignaturePolicy_SignedBy:
+		return []combination{{r.SignedBy: 1}}
+
+	case *common.SignaturePolicy_NOutOf_:
+		if r.NOutOf.GetN() <= 0 {
+			return []combination{{}}
+		}
+
+		rules := r.NOutOf.GetRules()
+		ruleCombinations := make([][]combination, len(rules))
+		for i, subRule := range rules {
+			ruleCombinations[i] = combinations(subRule)
+		}
+
+		var result []combination
+		forEachSubset(len(rules), int(r.NOutOf.GetN()), func(rules []int) {
+			product := []combination{{}}
+			for _, i := range rules {
+				product = multiply(product, ruleCombinations[i])
+			}
+			result = append(result, product...)
+		})
+		return result
+	}
+
+	return nil
+}
+
+// forEachSubset calls f for each subset of size k of 0..n-1
+func forEachSubset(n, k int, f func([]int)) {
+	if k > n {
+		return
+	}
+
+	subset := make([]int, 0, k)
+	var walk func(start int)
+	walk = func(start int) {
+		if len(subset) == k {
+			f(subset)
+			return
+		}
+		for i := start; i <= n-(k-len(subset)); i++ {
+			subset = append(subset, i)
+			walk(i + 1)
+			subset = subset[:len(subset)-1]
+		}
+	}
+	walk(0)
+}
+
+func multiply(a, b []combination) []combination {
+	result := make([]combination, 0, len(a)*len(b))
+	for _, ca := range a {
+		for _, cb := range b {
+			c := make(combination, len(ca)+len(cb))
+			for i, count := range ca {
+				c[i] += count
+			}
+			for i, count := range cb {
+				c[i] += count
+			}
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// minimize removes combinations covered by other combination and duplicates.
+// Result does not depend on order of principals: combination of the same size can be covered by later one
+func minimize(combinations []combination, principals []*mspproto.MSPPrincipal) []combination {
+	sort.SliceStable(combinations, func(i, j int) bool {
+		return combinations[i].size() < combinations[j].size()
+	})
+
+	var minimal []combination
+	for _, c := range combinations {
+		covered := false
+		for _, m := range minimal {
+			if m.covers(c, principals) {
+				covered = true
+				break
+			}
+		}
+		if covered {
+			continue
+		}
+
+		// drop combinations requiring stronger principals than c
+		kept := minimal[:0]
+		for _, m := range minimal {
+			if !c.covers(m, principals) {
+				kept = append(kept, m)
+			}
+		}
+		minimal = append(kept, c)
+	}
+
+	return minimal
+}
+
+func (c combination) size() int {
+	size := 0
+	for _, count := range c {
+		size += count
+	}
+	return size
+}
+
+// list returns principal indexes, index is repeated if principal must be satisfied by several identities
+func (c combination) list() []int32 {
+	var list []int32
+	for i, count := range c {
+		for j := 0; j < count; j++ {
+			list = append(list, i)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i] < list[j] })
+	return list
+}
+
+func (c combination) principals(principals []*mspproto.MSPPrincipal) Combination {
+	var result Combination
+	for _, i := range c.list() {
+		result = append(result, principals[i])
+	}
+	return result
+}
+
+// covers returns true if each principal of c can be matched to distinct principal of other,
+// which implies it. Then identities satisfying other satisfy c too
+func (c combination) covers(other combination, principals []*mspproto.MSPPrincipal) bool {
+	required, available := c.list(), other.list()
+	if len(required) > len(available) {
+		return false
+	}
+
+	matched := make([]int, len(available))
+	for i := range matched {
+		matched[i] = -1
+	}
+
+	var match func(r int, visited []bool) bool
+	match = func(r int, visited []bool) bool {
+		for a := range available {
+			if visited[a] || !implies(principals[available[a]], principals[required[r]]) {
+				continue
+			}
+			visited[a] = true
+			if matched[a] < 0 || match(matched[a], visited) {
+				matched[a] = r
+				return true
+			}
+		}
+		return false
+	}
+
+	for r := range required {
+		if !match(r, make([]bool, len(available))) {
+			return false
+		}
+	}
+	return true
+}
+
+// implies returns true if identity satisfying strong principal always satisfies weak principal
+func implies(strong, weak *mspproto.MSPPrincipal) bool {
+	if proto.Equal(strong, weak) {
+		return true
+	}
+
+	if weak.PrincipalClassification != mspproto.MSPPrincipal_ROLE {
+		return false
+	}
+	weakRole := &mspproto.MSPRole{}
+	if err := proto.Unmarshal(weak.Principal, weakRole); err != nil || weakRole.Role != mspproto.MSPRole_MEMBER {
+		return false
+	}
+
+	mspID, ok := principalMSPID(strong)
+	return ok && mspID == weakRole.MspIdentifier
+}
+
+// principalMSPID returns MSP ID of role, OU or identity principal
+func principalMSPID(principal *mspproto.MSPPrincipal) (string, bool) {
+	switch principal.PrincipalClassification {
+	case mspproto.MSPPrincipal_ROLE:
+		role := &mspproto.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err != nil {
+			return ``, false
+		}
+		return role.MspIdentifier, true
+
+	case mspproto.MSPPrincipal_ORGANIZATION_UNIT:
+		ou := &mspproto.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err != nil {
+			return ``, false
+		}
+		return ou.MspIdentifier, true
+
+	case mspproto.MSPPrincipal_IDENTITY:
+		serialized := &mspproto.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, serialized); err != nil {
+			return ``, false
+		}
+		return serialized.Mspid, true
+	}
+
+	return ``, false
+}
+
+func containsBytes(list [][]byte, b []byte) bool {
+	for _, item := range list {
+		if bytes.Equal(item, b) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// certificate parses PEM or DER encoded certificate
+func certificate(raw []byte) (*x509.Certificate, error) {
+	if block, _ := pem.Decode(raw); block != nil {
+		raw = block.Bytes
+	}
+
+	cert, err := x509.ParseCertificate(raw)
+	if err != nil {
+		return nil, fmt.Errorf(`parse certificate: %w`, err)
+	}
+	return cert, nil
+}
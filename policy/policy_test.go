@@ -0,0 +1,171 @@
+package policy_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/common/policydsl"
+
+	"github.com/vitiko/hlf-sdk-go/policy"
+)
+
+var (
+	org1Peer   = &policy.Attributes{MSPID: `Org1MSP`, Role: mspproto.MSPRole_PEER, OUs: []string{policy.PeerOU}}
+	org1Admin  = &policy.Attributes{MSPID: `Org1MSP`, Role: mspproto.MSPRole_ADMIN, OUs: []string{policy.AdminOU}}
+	org2Peer   = &policy.Attributes{MSPID: `Org2MSP`, Role: mspproto.MSPRole_PEER, OUs: []string{policy.PeerOU}}
+	org2Client = &policy.Attributes{MSPID: `Org2MSP`, Role: mspproto.MSPRole_CLIENT, OUs: []string{policy.ClientOU, `department1`}}
+)
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	for _, c := range []struct {
+		policy     string
+		identities []policy.Identity
+		satisfied  bool
+	}{
+		{`OR('Org1MSP.member', 'Org2MSP.member')`, []policy.Identity{org2Client}, true},
+		{`AND('Org1MSP.peer', 'Org2MSP.peer')`, []policy.Identity{org1Peer}, false},
+		{`AND('Org1MSP.peer', 'Org2MSP.peer')`, []policy.Identity{org2Peer, org1Peer}, true},
+		{`AND('Org1MSP.admin', 'Org1MSP.peer')`, []policy.Identity{org1Admin}, false},
+		{`AND('Org1MSP.member', 'Org1MSP.member')`, []policy.Identity{org1Admin, org1Peer}, true},
+		{`OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org2MSP.client')`, []policy.Identity{org2Peer, org2Client}, true},
+		{`OR('Org2MSP.admin', 'Org1MSP.client')`, []policy.Identity{org1Peer, org2Client}, false},
+	} {
+		evaluator, err := policy.FromString(c.policy)
+		if err != nil {
+			t.Fatalf("policy=%s: %s", c.policy, err)
+		}
+
+		err = evaluator.Evaluate(c.identities...)
+		if c.satisfied && err != nil {
+			t.Fatalf("policy=%s: expected satisfied, got %s", c.policy, err)
+		}
+		if !c.satisfied && !errors.Is(err, policy.ErrPolicyNotSatisfied) {
+			t.Fatalf("policy=%s: expected= %s, got= %v", c.policy, policy.ErrPolicyNotSatisfied, err)
+		}
+	}
+}
+
+func TestAttributes_SatisfiesPrincipal(t *testing.T) {
+	ou := &mspproto.MSPPrincipal{
+		PrincipalClassification: mspproto.MSPPrincipal_ORGANIZATION_UNIT,
+		Principal: marshal(t, &mspproto.OrganizationUnit{
+			MspIdentifier:                `Org2MSP`,
+			OrganizationalUnitIdentifier: `department1`,
+		}),
+	}
+
+	if err := org2Client.SatisfiesPrincipal(ou); err != nil {
+		t.Fatalf("ou principal: %s", err)
+	}
+	if err := org2Peer.SatisfiesPrincipal(ou); !errors.Is(err, policy.ErrPrincipalNotSatisfied) {
+		t.Fatalf("ou principal: expected= %s, got= %v", policy.ErrPrincipalNotSatisfied, err)
+	}
+}
+
+func endorsement(t *testing.T, mspID, mspPath string) *peer.Endorsement {
+	certs, err := filepath.Glob(filepath.Join(mspPath, `signcerts`, `*.pem`))
+	if err != nil || len(certs) == 0 {
+		t.Fatalf("sign cert not found in %s", mspPath)
+	}
+
+	cert, err := ioutil.ReadFile(certs[0])
+	if err != nil {
+		t.Fatalf("read cert: %s", err)
+	}
+
+	return &peer.Endorsement{Endorser: marshal(t, &mspproto.SerializedIdentity{Mspid: mspID, IdBytes: cert})}
+}
+
+func TestEvaluator_EvaluateEndorsements(t *testing.T) {
+	peerEndorsement := endorsement(t, `Org1MSP`, `../identity/testdata/Org1MSPPeer`)
+	adminEndorsement := endorsement(t, `Org1MSP`, `../identity/testdata/Org1MSPAdmin`)
+
+	evaluator, err := policy.FromString(`AND('Org1MSP.peer', 'Org1MSP.admin')`)
+	if err != nil {
+		t.Fatalf("policy: %s", err)
+	}
+
+	if err = evaluator.EvaluateEndorsements([]*peer.Endorsement{peerEndorsement, adminEndorsement}); err != nil {
+		t.Fatalf("evaluate: %s", err)
+	}
+
+	// the same endorser is counted once
+	if err = evaluator.EvaluateEndorsements([]*peer.Endorsement{peerEndorsement, peerEndorsement}); !errors.Is(err, policy.ErrPolicyNotSatisfied) {
+		t.Fatalf("evaluate duplicates: expected= %s, got= %v", policy.ErrPolicyNotSatisfied, err)
+	}
+}
+
+func TestEvaluator_Combinations(t *testing.T) {
+	for _, c := range []struct {
+		policy       string
+		combinations []string
+	}{
+		{`AND('Org1MSP.peer', 'Org2MSP.peer')`, []string{`Org1MSP.PEER Org2MSP.PEER`}},
+		{`OR('Org1MSP.peer', AND('Org2MSP.peer', 'Org3MSP.peer'))`, []string{`Org1MSP.PEER`, `Org2MSP.PEER Org3MSP.PEER`}},
+		{`OutOf(2, 'Org1MSP.peer', 'Org2MSP.peer', 'Org3MSP.peer')`,
+			[]string{`Org1MSP.PEER Org2MSP.PEER`, `Org1MSP.PEER Org3MSP.PEER`, `Org2MSP.PEER Org3MSP.PEER`}},
+		// member is weaker than peer, combination with peer is not minimal
+		{`OR('Org1MSP.member', 'Org1MSP.peer')`, []string{`Org1MSP.MEMBER`}},
+		{`OR('Org1MSP.peer', 'Org1MSP.member')`, []string{`Org1MSP.MEMBER`}},
+		{`OR(AND('Org1MSP.peer', 'Org2MSP.peer'), AND('Org1MSP.member', 'Org2MSP.member'))`,
+			[]string{`Org1MSP.MEMBER Org2MSP.MEMBER`}},
+		{`OR(AND('Org1MSP.member', 'Org2MSP.member'), AND('Org1MSP.peer', 'Org2MSP.peer'), 'Org3MSP.admin')`,
+			[]string{`Org3MSP.ADMIN`, `Org1MSP.MEMBER Org2MSP.MEMBER`}},
+		// principal required twice needs two identities
+		{`AND('Org1MSP.member', OR('Org1MSP.member', 'Org2MSP.member'))`,
+			[]string{`Org1MSP.MEMBER Org1MSP.MEMBER`, `Org1MSP.MEMBER Org2MSP.MEMBER`}},
+	} {
+		evaluator, err := policy.FromString(c.policy)
+		if err != nil {
+			t.Fatalf("policy=%s: %s", c.policy, err)
+		}
+
+		var combinations []string
+		for _, combination := range evaluator.Combinations() {
+			var principals []string
+			for _, principal := range combination {
+				role := &mspproto.MSPRole{}
+				if err = proto.Unmarshal(principal.Principal, role); err != nil {
+					t.Fatalf("unmarshal role: %s", err)
+				}
+				principals = append(principals, role.MspIdentifier+`.`+role.Role.String())
+			}
+			sort.Strings(principals)
+			combinations = append(combinations, strings.Join(principals, ` `))
+		}
+
+		sort.Strings(combinations)
+		sort.Strings(c.combinations)
+		if strings.Join(combinations, `, `) != strings.Join(c.combinations, `, `) {
+			t.Fatalf("policy=%s: expected= %v, got= %v", c.policy, c.combinations, combinations)
+		}
+	}
+}
+
+func TestNew_NOutOfNil(t *testing.T) {
+	for _, rule := range []*common.SignaturePolicy{
+		{Type: &common.SignaturePolicy_NOutOf_{}},
+		policydsl.NOutOf(1, []*common.SignaturePolicy{{Type: &common.SignaturePolicy_NOutOf_{}}}),
+	} {
+		_, err := policy.New(&common.SignaturePolicyEnvelope{Rule: rule})
+		if !errors.Is(err, policy.ErrPolicyEmpty) {
+			t.Fatalf("new: expected= %s, got= %v", policy.ErrPolicyEmpty, err)
+		}
+	}
+}
+
+func marshal(t *testing.T, msg proto.Message) []byte {
+	b, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
@@ -48,7 +48,7 @@ func ParseOrdererIdentity(cb *common.Block) (*msp.SerializedIdentity, error) {
 	if len(meta.Signatures) == 0 {
 		return nil, nil
 	}
-	// first signature by orderer
+	// first signature by orderer, use ParseOrdererIdentities for all signers
 	signatureHeader, err := protoutil.UnmarshalSignatureHeader(meta.Signatures[0].SignatureHeader)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshalling header signature in block #%v. err: %w", cb.Header.Number, err)
@@ -64,6 +64,31 @@ func ParseOrdererIdentity(cb *common.Block) (*msp.SerializedIdentity, error) {
 	return serializedIndentity, nil
 }
 
+// ParseOrdererIdentities returns identities of all orderers signed block
+func ParseOrdererIdentities(cb *common.Block) ([]*msp.SerializedIdentity, error) {
+	meta, err := protoutil.GetMetadataFromBlock(cb, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return nil, fmt.Errorf("fetching metadata from block#%v. err %w", cb.Header.Number, err)
+	}
+
+	var identities []*msp.SerializedIdentity
+	for i, sig := range meta.Signatures {
+		signatureHeader, err := protoutil.UnmarshalSignatureHeader(sig.SignatureHeader)
+		if err != nil {
+			return nil, fmt.Errorf("unmarshalling header signature #%d in block #%v. err: %w", i, cb.Header.Number, err)
+		}
+
+		serializedIdentity := &msp.SerializedIdentity{}
+		if err = proto.Unmarshal(signatureHeader.Creator, serializedIdentity); err != nil {
+			return nil, fmt.Errorf("unmarshalling serialized indentity #%d in block #%v. err: %w", i, cb.Header.Number, err)
+		}
+
+		identities = append(identities, serializedIdentity)
+	}
+
+	return identities, nil
+}
+
 func (b *Block) ValidEnvelopes() Envelopes {
 	var envs Envelopes
 	for _, e := range b.Envelopes {
@@ -0,0 +1,394 @@
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	mspproto "github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/bccsp/sw"
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protoutil"
+)
+
+const (
+	// BlockValidationPolicyKey - policy of orderer group (/Channel/Orderer/BlockValidation)
+	BlockValidationPolicyKey = `BlockValidation`
+	// BlockValidationSubPolicy - sub policy of orderer orgs, used in default BlockValidation policy
+	BlockValidationSubPolicy = `Writers`
+)
+
+var (
+	// ErrBlockNumberGap and ErrBlockPreviousHashMismatch are chain check errors,
+	// shared by block verifier, deliver block subscription and block walkers of state and history
+	ErrBlockNumberGap            = errors.New(`block number gap`)
+	ErrBlockPreviousHashMismatch = errors.New(`block previous hash mismatch`)
+
+	ErrBlockDataHashMismatch         = errors.New(`block data hash mismatch`)
+	ErrBlockValidationPolicyFailed   = errors.New(`block signatures do not satisfy BlockValidation policy`)
+	ErrBlockLastConfigInconsistent   = errors.New(`block last config index inconsistent`)
+	ErrBlockVerifierNoOrdererMSP     = errors.New(`no orderer MSP in channel config`)
+	ErrBlockValidationPolicyNotFound = errors.New(`BlockValidation sub policy not found`)
+)
+
+type (
+	// BlockVerifier checks block integrity and orderer signatures against channel configuration.
+	// Verifier is stateful: each verified block is used as previous for next one,
+	// config blocks update channel configuration used for verification of next blocks
+	BlockVerifier struct {
+		policy *Policy
+		// policy set by option is not replaced with default on config block
+		fixedPolicy bool
+
+		config       *ChannelConfig
+		mspManager   msp.MSPManager
+		ordererOrgs  map[string]*OrdererConfig
+		prevHeader   *common.BlockHeader
+		lastConfig   uint64
+		lastConfigOk bool
+	}
+
+	BlockVerifierOpt func(*BlockVerifier)
+)
+
+// WithBlockValidationPolicy sets BlockValidation policy (/Channel/Orderer/BlockValidation).
+// By default policy is taken from channel config, ImplicitMeta ANY Writers of orderer orgs is used if it is absent
+func WithBlockValidationPolicy(policy *Policy) BlockVerifierOpt {
+	return func(v *BlockVerifier) {
+		v.policy = policy
+		v.fixedPolicy = true
+	}
+}
+
+// WithPreviousBlock sets header of previous block and index of last config block,
+// used when verification starts not from genesis block
+func WithPreviousBlock(header *common.BlockHeader, lastConfigIndex uint64) BlockVerifierOpt {
+	return func(v *BlockVerifier) {
+		v.prevHeader = header
+		v.lastConfig = lastConfigIndex
+		v.lastConfigOk = true
+	}
+}
+
+func NewBlockVerifier(config *ChannelConfig, opts ...BlockVerifierOpt) (*BlockVerifier, error) {
+	v := &BlockVerifier{}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if err := v.setConfig(config); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Verify checks data hash, previous hash, orderer signatures and last config index.
+// On success block header becomes previous for next verification
+func (v *BlockVerifier) Verify(block *common.Block) error {
+	if block.GetHeader() == nil || block.GetData() == nil || block.GetMetadata() == nil {
+		return errors.New(`block header, data or metadata is empty`)
+	}
+	num := block.Header.Number
+
+	if !bytes.Equal(block.Header.DataHash, protoutil.BlockDataHash(block.Data)) {
+		return fmt.Errorf(`block=%d: %w`, num, ErrBlockDataHashMismatch)
+	}
+
+	if v.prevHeader != nil {
+		if num != v.prevHeader.Number+1 {
+			return fmt.Errorf(`block=%d, expected=%d: %w`, num, v.prevHeader.Number+1, ErrBlockNumberGap)
+		}
+		if !bytes.Equal(block.Header.PreviousHash, protoutil.BlockHeaderHash(v.prevHeader)) {
+			return fmt.Errorf(`block=%d: %w`, num, ErrBlockPreviousHashMismatch)
+		}
+	}
+
+	configEnvelope, err := blockConfigEnvelope(block)
+	if err != nil {
+		return fmt.Errorf(`block=%d: %w`, num, err)
+	}
+
+	lastConfig, err := v.verifyLastConfig(block, configEnvelope != nil)
+	if err != nil {
+		return fmt.Errorf(`block=%d: %w`, num, err)
+	}
+
+	// genesis block has no orderer signatures
+	if num > 0 {
+		if err = v.verifySignatures(block); err != nil {
+			return fmt.Errorf(`block=%d: %w`, num, err)
+		}
+	}
+
+	if configEnvelope != nil {
+		config, err := ParseChannelConfig(*configEnvelope.Config)
+		if err != nil {
+			return fmt.Errorf(`block=%d: parse channel config: %w`, num, err)
+		}
+		if err = v.setConfig(config); err != nil {
+			return fmt.Errorf(`block=%d: %w`, num, err)
+		}
+	}
+
+	v.prevHeader = block.Header
+	v.lastConfig, v.lastConfigOk = lastConfig, true
+
+	return nil
+}
+
+// Config returns channel config used for verification of next block
+func (v *BlockVerifier) Config() *ChannelConfig {
+	return v.config
+}
+
+// verifyLastConfig checks that last config index is not greater than block number,
+// points to block itself for config block and does not change between config blocks
+func (v *BlockVerifier) verifyLastConfig(block *common.Block, isConfig bool) (uint64, error) {
+	num := block.Header.Number
+
+	// genesis block may have no last config metadata
+	if num == 0 {
+		return 0, nil
+	}
+
+	lastConfig, err := protoutil.GetLastConfigIndexFromBlock(block)
+	if err != nil {
+		return 0, fmt.Errorf(`get last config index: %w`, err)
+	}
+
+	switch {
+	case lastConfig > num:
+		return 0, fmt.Errorf(`index=%d greater than block number: %w`, lastConfig, ErrBlockLastConfigInconsistent)
+	case isConfig && lastConfig != num:
+		return 0, fmt.Errorf(`config block has last config index=%d: %w`, lastConfig, ErrBlockLastConfigInconsistent)
+	case !isConfig && v.lastConfigOk && lastConfig != v.lastConfig:
+		return 0, fmt.Errorf(`index=%d, expected=%d: %w`, lastConfig, v.lastConfig, ErrBlockLastConfigInconsistent)
+	}
+
+	return lastConfig, nil
+}
+
+// verifySignatures evaluates BlockValidation policy against orderers with valid signatures.
+// As in fabric, invalid signatures are dropped and don't fail block by themselves
+func (v *BlockVerifier) verifySignatures(block *common.Block) error {
+	meta, err := protoutil.GetMetadataFromBlock(block, common.BlockMetadataIndex_SIGNATURES)
+	if err != nil {
+		return fmt.Errorf(`get signatures metadata: %w`, err)
+	}
+
+	headerBytes := protoutil.BlockHeaderBytes(block.Header)
+	var (
+		signers []msp.Identity
+		invalid []string
+	)
+
+	for i, sig := range meta.Signatures {
+		id, err := v.verifySignature(meta.Value, sig, headerBytes)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf(`signature=%d: %s`, i, err))
+			continue
+		}
+		signers = append(signers, id)
+	}
+
+	if err = v.evaluatePolicy(signers); err != nil {
+		if len(invalid) > 0 {
+			return fmt.Errorf(`%w, dropped invalid signatures: %s`, err, strings.Join(invalid, `; `))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// verifySignature returns orderer identity if signature of block header and metadata value is valid
+func (v *BlockVerifier) verifySignature(
+	metaValue []byte, sig *common.MetadataSignature, headerBytes []byte) (msp.Identity, error) {
+
+	sigHeader, err := protoutil.UnmarshalSignatureHeader(sig.SignatureHeader)
+	if err != nil {
+		return nil, fmt.Errorf(`header: %w`, err)
+	}
+
+	id, err := v.mspManager.DeserializeIdentity(sigHeader.Creator)
+	if err != nil {
+		return nil, fmt.Errorf(`deserialize identity: %w`, err)
+	}
+
+	if err = id.Validate(); err != nil {
+		return nil, fmt.Errorf(`validate identity: %w`, err)
+	}
+
+	if err = id.Verify(util.ConcatenateBytes(metaValue, sig.SignatureHeader, headerBytes), sig.Signature); err != nil {
+		return nil, fmt.Errorf(`verify: %w`, err)
+	}
+
+	return id, nil
+}
+
+func (v *BlockVerifier) evaluatePolicy(signers []msp.Identity) error {
+	if sigPolicy := v.policy.GetSignaturePolicy(); sigPolicy != nil {
+		return v.evaluateSignaturePolicy(sigPolicy, signers)
+	}
+
+	implicit := v.policy.GetImplicit()
+	if implicit == nil {
+		return fmt.Errorf(`policy type is not supported: %w`, ErrBlockValidationPolicyFailed)
+	}
+
+	satisfied := 0
+	for _, org := range v.ordererOrgs {
+		orgPolicy, err := ordererOrgPolicy(org, implicit.SubPolicy)
+		if err != nil {
+			return err
+		}
+		if v.evaluateSignaturePolicy(orgPolicy, signers) == nil {
+			satisfied++
+		}
+	}
+
+	var required int
+	switch implicit.Rule {
+	case common.ImplicitMetaPolicy_ANY:
+		required = 1
+	case common.ImplicitMetaPolicy_ALL:
+		required = len(v.ordererOrgs)
+	case common.ImplicitMetaPolicy_MAJORITY:
+		required = len(v.ordererOrgs)/2 + 1
+	}
+
+	if satisfied < required {
+		return fmt.Errorf(`%s %s: satisfied by %d of %d orderer orgs: %w`,
+			implicit.Rule, implicit.SubPolicy, satisfied, len(v.ordererOrgs), ErrBlockValidationPolicyFailed)
+	}
+
+	return nil
+}
+
+func (v *BlockVerifier) evaluateSignaturePolicy(sigPolicy *common.SignaturePolicyEnvelope, signers []msp.Identity) error {
+	policy, err := (&cauthdsl.EnvelopeBasedPolicyProvider{Deserializer: v.mspManager}).NewPolicy(sigPolicy)
+	if err != nil {
+		return fmt.Errorf(`compile signature policy: %w`, err)
+	}
+
+	if err = policy.EvaluateIdentities(signers); err != nil {
+		return fmt.Errorf(`%s: %w`, err, ErrBlockValidationPolicyFailed)
+	}
+
+	return nil
+}
+
+// setConfig creates orderer MSPs from channel config
+func (v *BlockVerifier) setConfig(config *ChannelConfig) error {
+	if len(config.GetOrderers()) == 0 {
+		return ErrBlockVerifierNoOrdererMSP
+	}
+
+	cryptoProvider, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return fmt.Errorf(`create crypto provider: %w`, err)
+	}
+
+	var msps []msp.MSP
+	for name, orderer := range config.Orderers {
+		fabricMSPConfig, err := proto.Marshal(orderer.GetMsp().GetConfig())
+		if err != nil {
+			return fmt.Errorf(`marshal orderer=%s msp config: %w`, name, err)
+		}
+
+		ordererMSP, err := msp.New(&msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_4_3}}, cryptoProvider)
+		if err != nil {
+			return fmt.Errorf(`create orderer=%s msp: %w`, name, err)
+		}
+
+		if err = ordererMSP.Setup(&mspproto.MSPConfig{Type: int32(msp.FABRIC), Config: fabricMSPConfig}); err != nil {
+			return fmt.Errorf(`setup orderer=%s msp: %w`, name, err)
+		}
+
+		msps = append(msps, ordererMSP)
+	}
+
+	mspManager := msp.NewMSPManager()
+	if err = mspManager.Setup(msps); err != nil {
+		return fmt.Errorf(`setup msp manager: %w`, err)
+	}
+
+	v.config = config
+	v.mspManager = mspManager
+	v.ordererOrgs = config.Orderers
+
+	if v.fixedPolicy {
+		return nil
+	}
+
+	if policy, ok := config.GetOrdererPolicy()[BlockValidationPolicyKey]; ok {
+		v.policy = policy
+	} else {
+		v.policy = &Policy{
+			Policy: &Policy_Implicit{Implicit: &common.ImplicitMetaPolicy{
+				Rule:      common.ImplicitMetaPolicy_ANY,
+				SubPolicy: BlockValidationSubPolicy,
+			}},
+		}
+	}
+
+	return nil
+}
+
+// ordererOrgPolicy returns signature sub policy of orderer org, or member of org MSP if policy is not in config
+func ordererOrgPolicy(org *OrdererConfig, subPolicy string) (*common.SignaturePolicyEnvelope, error) {
+	if policy, ok := org.GetMsp().GetPolicy()[subPolicy]; ok {
+		if sigPolicy := policy.GetSignaturePolicy(); sigPolicy != nil {
+			return sigPolicy, nil
+		}
+		return nil, fmt.Errorf(`orderer=%s policy=%s is not signature policy: %w`,
+			org.Name, subPolicy, ErrBlockValidationPolicyNotFound)
+	}
+
+	mspID := org.GetMsp().GetConfig().GetName()
+	if mspID == `` {
+		return nil, fmt.Errorf(`orderer=%s policy=%s: %w`, org.Name, subPolicy, ErrBlockValidationPolicyNotFound)
+	}
+
+	return policydsl.SignedByMspMember(mspID), nil
+}
+
+// blockConfigEnvelope returns config envelope if block is config block, nil otherwise
+func blockConfigEnvelope(block *common.Block) (*common.ConfigEnvelope, error) {
+	if len(block.Data.Data) != 1 {
+		return nil, nil
+	}
+
+	envelope, err := protoutil.ExtractEnvelope(block, 0)
+	if err != nil {
+		return nil, fmt.Errorf(`extract envelope: %w`, err)
+	}
+
+	payload, err := protoutil.UnmarshalPayload(envelope.Payload)
+	if err != nil {
+		return nil, fmt.Errorf(`payload from envelope: %w`, err)
+	}
+
+	channelHeader, err := protoutil.UnmarshalChannelHeader(payload.GetHeader().GetChannelHeader())
+	if err != nil {
+		return nil, fmt.Errorf(`channel header from envelope payload: %w`, err)
+	}
+
+	if common.HeaderType(channelHeader.Type) != common.HeaderType_CONFIG {
+		return nil, nil
+	}
+
+	configEnvelope := &common.ConfigEnvelope{}
+	if err = proto.Unmarshal(payload.Data, configEnvelope); err != nil {
+		return nil, fmt.Errorf(`unmarshal config envelope: %w`, err)
+	}
+
+	return configEnvelope, nil
+}
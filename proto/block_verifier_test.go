@@ -0,0 +1,245 @@
+package proto_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protoutil"
+
+	"github.com/vitiko/hlf-sdk-go/crypto"
+	"github.com/vitiko/hlf-sdk-go/crypto/ecdsa"
+	"github.com/vitiko/hlf-sdk-go/identity"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+const ordererMSPPath = `../identity/testdata/Org1MSPPeer`
+
+func ordererChannelConfig(t *testing.T) *hlfproto.ChannelConfig {
+	caCert, err := ioutil.ReadFile(ordererMSPPath + `/cacerts/localhost-7054-ca-org1.pem`)
+	if err != nil {
+		t.Fatalf("read ca cert: %s", err)
+	}
+
+	return &hlfproto.ChannelConfig{
+		Orderers: map[string]*hlfproto.OrdererConfig{
+			`Org1`: {
+				Name: `Org1`,
+				Msp: &hlfproto.MSP{
+					Name: `Org1`,
+					Config: &msp.FabricMSPConfig{
+						Name:      `Org1MSP`,
+						RootCerts: [][]byte{caCert},
+						FabricNodeOus: &msp.FabricNodeOUs{
+							Enable:              true,
+							ClientOuIdentifier:  &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: `client`},
+							PeerOuIdentifier:    &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: `peer`},
+							AdminOuIdentifier:   &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: `admin`},
+							OrdererOuIdentifier: &msp.FabricOUIdentifier{OrganizationalUnitIdentifier: `orderer`},
+						},
+						CryptoConfig: &msp.FabricCryptoConfig{
+							SignatureHashFamily:            `SHA2`,
+							IdentityIdentifierHashFunction: `SHA256`,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func envelopeData(txID string) []byte {
+	return protoutil.MarshalOrPanic(&common.Envelope{Payload: protoutil.MarshalOrPanic(&common.Payload{
+		Header: &common.Header{ChannelHeader: protoutil.MarshalOrPanic(&common.ChannelHeader{
+			Type: int32(common.HeaderType_ENDORSER_TRANSACTION), TxId: txID})},
+	})})
+}
+
+// signedBlock creates block with one data item, signed by orderer identity
+func signedBlock(t *testing.T, number uint64, prev *common.BlockHeader, data []byte) *common.Block {
+	id, err := identity.SignerFromMSPPath(`Org1MSP`, ordererMSPPath)
+	if err != nil {
+		t.Fatalf("load identity: %s", err)
+	}
+	cs, err := crypto.GetSuite(ecdsa.Module, ecdsa.DefaultOpts)
+	if err != nil {
+		t.Fatalf("crypto suite: %s", err)
+	}
+	signer := id.GetSigningIdentity(cs)
+
+	var prevHash []byte
+	if prev != nil {
+		prevHash = protoutil.BlockHeaderHash(prev)
+	}
+
+	block := protoutil.NewBlock(number, prevHash)
+	block.Data.Data = [][]byte{data}
+	block.Header.DataHash = protoutil.BlockDataHash(block.Data)
+
+	creator, err := signer.Serialize()
+	if err != nil {
+		t.Fatalf("serialize signer: %s", err)
+	}
+	sigHeader := protoutil.MarshalOrPanic(&common.SignatureHeader{Creator: creator, Nonce: []byte(`nonce`)})
+	metaValue := protoutil.MarshalOrPanic(&common.OrdererBlockMetadata{LastConfig: &common.LastConfig{Index: 0}})
+
+	signature, err := signer.Sign(util.ConcatenateBytes(metaValue, sigHeader, protoutil.BlockHeaderBytes(block.Header)))
+	if err != nil {
+		t.Fatalf("sign block: %s", err)
+	}
+
+	block.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(&common.Metadata{
+		Value:      metaValue,
+		Signatures: []*common.MetadataSignature{{SignatureHeader: sigHeader, Signature: signature}},
+	})
+
+	return block
+}
+
+func TestBlockVerifier(t *testing.T) {
+	genesis := &common.BlockHeader{Number: 0}
+
+	adminPolicy := &hlfproto.Policy{Policy: &hlfproto.Policy_SignaturePolicy{
+		SignaturePolicy: policydsl.SignedByMspAdmin(`Org1MSP`)}}
+	memberPolicy := &hlfproto.Policy{Policy: &hlfproto.Policy_SignaturePolicy{
+		SignaturePolicy: policydsl.SignedByMspMember(`Org1MSP`)}}
+
+	tests := []struct {
+		name   string
+		block  func() *common.Block
+		policy *hlfproto.Policy
+		// BlockValidation policy of orderer group in channel config
+		configPolicy *hlfproto.Policy
+		err          error
+	}{
+		{name: `valid`, block: func() *common.Block {
+			return signedBlock(t, 1, genesis, envelopeData(`tx`))
+		}},
+		{name: `data tampered`, err: hlfproto.ErrBlockDataHashMismatch, block: func() *common.Block {
+			b := signedBlock(t, 1, genesis, envelopeData(`tx`))
+			b.Data.Data[0] = envelopeData(`tampered`)
+			return b
+		}},
+		{name: `previous hash`, err: hlfproto.ErrBlockPreviousHashMismatch, block: func() *common.Block {
+			return signedBlock(t, 1, &common.BlockHeader{Number: 0, DataHash: []byte(`other`)}, envelopeData(`tx`))
+		}},
+		{name: `header tampered`, err: hlfproto.ErrBlockValidationPolicyFailed, block: func() *common.Block {
+			b := signedBlock(t, 1, genesis, envelopeData(`tx`))
+			b.Data.Data[0] = envelopeData(`tampered`)
+			b.Header.DataHash = protoutil.BlockDataHash(b.Data)
+			return b
+		}},
+		{name: `invalid signature dropped`, block: func() *common.Block {
+			b := signedBlock(t, 1, genesis, envelopeData(`tx`))
+			meta, err := protoutil.GetMetadataFromBlock(b, common.BlockMetadataIndex_SIGNATURES)
+			if err != nil {
+				t.Fatalf("get signatures: %s", err)
+			}
+			invalid := &common.MetadataSignature{SignatureHeader: meta.Signatures[0].SignatureHeader, Signature: []byte(`invalid`)}
+			meta.Signatures = append([]*common.MetadataSignature{invalid}, meta.Signatures...)
+			b.Metadata.Metadata[common.BlockMetadataIndex_SIGNATURES] = protoutil.MarshalOrPanic(meta)
+			return b
+		}},
+		{name: `config policy satisfied`, configPolicy: memberPolicy, block: func() *common.Block {
+			return signedBlock(t, 1, genesis, envelopeData(`tx`))
+		}},
+		{name: `config policy not satisfied`, configPolicy: adminPolicy, err: hlfproto.ErrBlockValidationPolicyFailed,
+			block: func() *common.Block {
+				return signedBlock(t, 1, genesis, envelopeData(`tx`))
+			}},
+		{name: `policy option overrides config`, configPolicy: adminPolicy, policy: memberPolicy,
+			block: func() *common.Block {
+				return signedBlock(t, 1, genesis, envelopeData(`tx`))
+			}},
+		{name: `policy not satisfied`, err: hlfproto.ErrBlockValidationPolicyFailed,
+			policy: &hlfproto.Policy{Policy: &hlfproto.Policy_Implicit{Implicit: &common.ImplicitMetaPolicy{
+				Rule: common.ImplicitMetaPolicy_ANY, SubPolicy: `Admins`}}},
+			block: func() *common.Block {
+				return signedBlock(t, 1, genesis, envelopeData(`tx`))
+			}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := ordererChannelConfig(t)
+			var opts []hlfproto.BlockVerifierOpt
+			opts = append(opts, hlfproto.WithPreviousBlock(genesis, 0))
+			if tc.configPolicy != nil {
+				config.OrdererPolicy = map[string]*hlfproto.Policy{hlfproto.BlockValidationPolicyKey: tc.configPolicy}
+			}
+			if tc.policy != nil {
+				config.Orderers[`Org1`].Msp.Policy = map[string]*hlfproto.Policy{
+					`Admins`: {Policy: &hlfproto.Policy_SignaturePolicy{SignaturePolicy: &common.SignaturePolicyEnvelope{
+						Rule: &common.SignaturePolicy{Type: &common.SignaturePolicy_SignedBy{SignedBy: 0}},
+						Identities: []*msp.MSPPrincipal{{
+							PrincipalClassification: msp.MSPPrincipal_ROLE,
+							Principal:               protoutil.MarshalOrPanic(&msp.MSPRole{MspIdentifier: `Org1MSP`, Role: msp.MSPRole_ADMIN}),
+						}},
+					}}},
+				}
+				opts = append(opts, hlfproto.WithBlockValidationPolicy(tc.policy))
+			}
+
+			verifier, err := hlfproto.NewBlockVerifier(config, opts...)
+			if err != nil {
+				t.Fatalf("new verifier: %s", err)
+			}
+
+			err = verifier.Verify(tc.block())
+			if tc.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("error: expected= %s, got= %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestParseOrdererIdentities(t *testing.T) {
+	block := signedBlock(t, 1, nil, envelopeData(`tx`))
+
+	identities, err := hlfproto.ParseOrdererIdentities(block)
+	if err != nil {
+		t.Fatalf("parse orderer identities: %s", err)
+	}
+	if len(identities) != 1 || identities[0].Mspid != `Org1MSP` {
+		t.Fatalf("unexpected identities: %v", identities)
+	}
+
+	first, err := hlfproto.ParseOrdererIdentity(block)
+	if err != nil {
+		t.Fatalf("parse orderer identity: %s", err)
+	}
+	if !proto.Equal(first, identities[0]) {
+		t.Fatal("first identity mismatch")
+	}
+}
+
+func TestParseOrdererPolicy(t *testing.T) {
+	blockValidation := policydsl.SignedByMspMember(`OrdererMSP`)
+	config := common.Config{ChannelGroup: &common.ConfigGroup{Groups: map[string]*common.ConfigGroup{
+		`Orderer`: {Policies: map[string]*common.ConfigPolicy{
+			hlfproto.BlockValidationPolicyKey: {Policy: &common.Policy{
+				Type:  int32(common.Policy_SIGNATURE),
+				Value: protoutil.MarshalOrPanic(blockValidation),
+			}},
+		}},
+	}}}
+
+	policies, err := hlfproto.ParseOrdererPolicy(config)
+	if err != nil {
+		t.Fatalf("parse orderer policy: %s", err)
+	}
+	if !proto.Equal(policies[hlfproto.BlockValidationPolicyKey].GetSignaturePolicy(), blockValidation) {
+		t.Fatalf("unexpected BlockValidation policy: %v", policies[hlfproto.BlockValidationPolicyKey])
+	}
+}
@@ -0,0 +1,198 @@
+package proto
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+)
+
+const (
+	LifecycleNamespace = `_lifecycle`
+	LSCCNamespace      = `lscc`
+
+	lifecycleApproveFuncName = `ApproveChaincodeDefinitionForMyOrg`
+	lifecycleCommitFuncName  = `CommitChaincodeDefinition`
+	lsccDeployFuncName       = `deploy`
+	lsccUpgradeFuncName      = `upgrade`
+)
+
+type ChaincodeDefinitionEventType string
+
+const (
+	ChaincodeDefinitionApprove ChaincodeDefinitionEventType = `approve`
+	ChaincodeDefinitionCommit  ChaincodeDefinitionEventType = `commit`
+	ChaincodeDefinitionDeploy  ChaincodeDefinitionEventType = `deploy`
+	ChaincodeDefinitionUpgrade ChaincodeDefinitionEventType = `upgrade`
+)
+
+type (
+	// ChaincodeDefinitionEvent - chaincode definition change from _lifecycle or lscc transaction
+	ChaincodeDefinitionEvent struct {
+		Type      ChaincodeDefinitionEventType `json:"type"`
+		Chaincode string                       `json:"chaincode"`
+		// sequence is set only for _lifecycle definitions
+		Sequence int64  `json:"sequence,omitempty"`
+		Version  string `json:"version"`
+		// package id is set only for approve, if peer org has installed package
+		PackageID         string `json:"package_id,omitempty"`
+		EndorsementPlugin string `json:"endorsement_plugin,omitempty"`
+		ValidationPlugin  string `json:"validation_plugin,omitempty"`
+		// lscc signature policy is converted to application policy
+		EndorsementPolicy *peer.ApplicationPolicy       `json:"endorsement_policy,omitempty"`
+		Collections       *peer.CollectionConfigPackage `json:"collections,omitempty"`
+		InitRequired      bool                          `json:"init_required,omitempty"`
+		// MSP identifier of transaction creator, for approve - org approved definition
+		ApprovingOrg string `json:"approving_org"`
+	}
+)
+
+// ParseChaincodeDefinitionEvent returns chaincode definition event if action is _lifecycle approve / commit
+// or lscc deploy / upgrade invocation, nil otherwise
+func ParseChaincodeDefinitionEvent(action *TransactionAction) (*ChaincodeDefinitionEvent, error) {
+	spec := action.ChaincodeInvocationSpec.GetChaincodeSpec()
+	args := spec.GetInput().GetArgs()
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	var (
+		event *ChaincodeDefinitionEvent
+		err   error
+	)
+
+	switch spec.GetChaincodeId().GetName() {
+	case LifecycleNamespace:
+		event, err = parseLifecycleDefinition(string(args[0]), args[1:])
+	case LSCCNamespace:
+		event, err = parseLSCCDefinition(string(args[0]), args[1:])
+	default:
+		return nil, nil
+	}
+
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	event.ApprovingOrg = action.CreatorIdentity.Mspid
+	return event, nil
+}
+
+func parseLifecycleDefinition(fn string, args [][]byte) (*ChaincodeDefinitionEvent, error) {
+	if fn != lifecycleApproveFuncName && fn != lifecycleCommitFuncName {
+		return nil, nil
+	}
+
+	if len(args) == 0 {
+		return nil, fmt.Errorf(`_lifecycle %s: args are empty`, fn)
+	}
+
+	var event *ChaincodeDefinitionEvent
+	var validationParameter []byte
+
+	switch fn {
+	case lifecycleApproveFuncName:
+		approveArgs := &lifecycle.ApproveChaincodeDefinitionForMyOrgArgs{}
+		if err := proto.Unmarshal(args[0], approveArgs); err != nil {
+			return nil, fmt.Errorf(`_lifecycle %s args: %w`, fn, err)
+		}
+
+		event = &ChaincodeDefinitionEvent{
+			Type:              ChaincodeDefinitionApprove,
+			Chaincode:         approveArgs.Name,
+			Sequence:          approveArgs.Sequence,
+			Version:           approveArgs.Version,
+			PackageID:         approveArgs.GetSource().GetLocalPackage().GetPackageId(),
+			EndorsementPlugin: approveArgs.EndorsementPlugin,
+			ValidationPlugin:  approveArgs.ValidationPlugin,
+			Collections:       approveArgs.Collections,
+			InitRequired:      approveArgs.InitRequired,
+		}
+		validationParameter = approveArgs.ValidationParameter
+
+	case lifecycleCommitFuncName:
+		commitArgs := &lifecycle.CommitChaincodeDefinitionArgs{}
+		if err := proto.Unmarshal(args[0], commitArgs); err != nil {
+			return nil, fmt.Errorf(`_lifecycle %s args: %w`, fn, err)
+		}
+
+		event = &ChaincodeDefinitionEvent{
+			Type:              ChaincodeDefinitionCommit,
+			Chaincode:         commitArgs.Name,
+			Sequence:          commitArgs.Sequence,
+			Version:           commitArgs.Version,
+			EndorsementPlugin: commitArgs.EndorsementPlugin,
+			ValidationPlugin:  commitArgs.ValidationPlugin,
+			Collections:       commitArgs.Collections,
+			InitRequired:      commitArgs.InitRequired,
+		}
+		validationParameter = commitArgs.ValidationParameter
+	}
+
+	if len(validationParameter) > 0 {
+		event.EndorsementPolicy = &peer.ApplicationPolicy{}
+		if err := proto.Unmarshal(validationParameter, event.EndorsementPolicy); err != nil {
+			return nil, fmt.Errorf(`_lifecycle %s validation parameter: %w`, fn, err)
+		}
+	}
+
+	return event, nil
+}
+
+// parseLSCCDefinition parses lscc deploy / upgrade args:
+// channel, chaincode deployment spec, policy, escc, vscc, collection config
+func parseLSCCDefinition(fn string, args [][]byte) (*ChaincodeDefinitionEvent, error) {
+	var eventType ChaincodeDefinitionEventType
+	switch fn {
+	case lsccDeployFuncName:
+		eventType = ChaincodeDefinitionDeploy
+	case lsccUpgradeFuncName:
+		eventType = ChaincodeDefinitionUpgrade
+	default:
+		return nil, nil
+	}
+
+	if len(args) < 2 {
+		return nil, fmt.Errorf(`lscc %s: expected at least 2 args, got %d`, fn, len(args))
+	}
+
+	cds := &peer.ChaincodeDeploymentSpec{}
+	if err := proto.Unmarshal(args[1], cds); err != nil {
+		return nil, fmt.Errorf(`lscc %s chaincode deployment spec: %w`, fn, err)
+	}
+
+	event := &ChaincodeDefinitionEvent{
+		Type:      eventType,
+		Chaincode: cds.GetChaincodeSpec().GetChaincodeId().GetName(),
+		Version:   cds.GetChaincodeSpec().GetChaincodeId().GetVersion(),
+	}
+
+	if len(args) > 2 && len(args[2]) > 0 {
+		signaturePolicy := &common.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(args[2], signaturePolicy); err != nil {
+			return nil, fmt.Errorf(`lscc %s endorsement policy: %w`, fn, err)
+		}
+		event.EndorsementPolicy = &peer.ApplicationPolicy{
+			Type: &peer.ApplicationPolicy_SignaturePolicy{SignaturePolicy: signaturePolicy},
+		}
+	}
+
+	if len(args) > 3 {
+		event.EndorsementPlugin = string(args[3])
+	}
+
+	if len(args) > 4 {
+		event.ValidationPlugin = string(args[4])
+	}
+
+	if len(args) > 5 && len(args[5]) > 0 {
+		event.Collections = &peer.CollectionConfigPackage{}
+		if err := proto.Unmarshal(args[5], event.Collections); err != nil {
+			return nil, fmt.Errorf(`lscc %s collection config: %w`, fn, err)
+		}
+	}
+
+	return event, nil
+}
@@ -117,6 +117,12 @@ func ParseChannelConfig(cc common.Config) (*ChannelConfig, error) {
 
 	chanCfg.Policy = policies
 
+	ordererPolicies, err := ParseOrdererPolicy(cc)
+	if err != nil {
+		return nil, fmt.Errorf("parse orderer policies: %w", err)
+	}
+	chanCfg.OrdererPolicy = ordererPolicies
+
 	return chanCfg, nil
 }
 
@@ -211,6 +217,15 @@ func ParseOrderer(cfg common.Config) (map[string]*OrdererConfig, error) {
 	return orderersCfg, nil
 }
 
+// ParseOrdererPolicy returns policies of orderer group, nil if there is no orderer group in config
+func ParseOrdererPolicy(cfg common.Config) (map[string]*Policy, error) {
+	ordererGroup, exists := cfg.ChannelGroup.Groups[channelconfig.OrdererGroupKey]
+	if !exists {
+		return nil, nil
+	}
+	return ParsePolicy(ordererGroup.Policies)
+}
+
 func ParseOrdererEndpoints(b []byte) ([]string, error) {
 	oa := &common.OrdererAddresses{}
 	if err := proto.Unmarshal(b, oa); err != nil {
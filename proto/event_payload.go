@@ -0,0 +1,98 @@
+package proto
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+)
+
+var (
+	ErrEventPayloadTargetNil = errors.New(`event payload target is nil`)
+)
+
+type (
+	// EventPayloadDecoder converts raw chaincode event payload to go type
+	EventPayloadDecoder func(payload []byte) (interface{}, error)
+
+	// EventPayloadRegistry maps chaincode event names to payload decoders
+	EventPayloadRegistry struct {
+		mu       sync.RWMutex
+		decoders map[string]EventPayloadDecoder
+	}
+)
+
+func NewEventPayloadRegistry() *EventPayloadRegistry {
+	return &EventPayloadRegistry{
+		decoders: make(map[string]EventPayloadDecoder),
+	}
+}
+
+// Register maps event name to type of target.
+// Payload is unmarshalled as protobuf if target is proto.Message, as JSON otherwise.
+// Decoded payload has the same type as target (pointer to new value). Target is used only for type,
+// nil and typed nil targets, i.e. (*T)(nil), are rejected with ErrEventPayloadTargetNil
+func (r *EventPayloadRegistry) Register(eventName string, target interface{}) error {
+	if target == nil {
+		return ErrEventPayloadTargetNil
+	}
+
+	targetValue := reflect.ValueOf(target)
+	switch targetValue.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func, reflect.Chan:
+		if targetValue.IsNil() {
+			return fmt.Errorf(`%s: %w`, targetValue.Type(), ErrEventPayloadTargetNil)
+		}
+	}
+
+	if _, ok := target.(proto.Message); ok && targetValue.Kind() == reflect.Ptr {
+		msgType := targetValue.Type().Elem()
+		r.RegisterDecoder(eventName, func(payload []byte) (interface{}, error) {
+			decoded := reflect.New(msgType).Interface().(proto.Message)
+			if err := proto.Unmarshal(payload, decoded); err != nil {
+				return nil, fmt.Errorf(`unmarshal proto to %s: %w`, reflect.TypeOf(target), err)
+			}
+			return decoded, nil
+		})
+		return nil
+	}
+
+	targetType := reflect.TypeOf(target)
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	r.RegisterDecoder(eventName, func(payload []byte) (interface{}, error) {
+		decoded := reflect.New(targetType).Interface()
+		if err := json.Unmarshal(payload, decoded); err != nil {
+			return nil, fmt.Errorf(`unmarshal json to %s: %w`, reflect.TypeOf(target), err)
+		}
+		return decoded, nil
+	})
+
+	return nil
+}
+
+// RegisterDecoder maps event name to custom decoder
+func (r *EventPayloadRegistry) RegisterDecoder(eventName string, decoder EventPayloadDecoder) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[eventName] = decoder
+}
+
+// Decode returns decoded payload. If there is no decoder for event name, registered is false
+func (r *EventPayloadRegistry) Decode(eventName string, payload []byte) (decoded interface{}, registered bool, err error) {
+	r.mu.RLock()
+	decoder, ok := r.decoders[eventName]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	decoded, err = decoder(payload)
+	return decoded, true, err
+}
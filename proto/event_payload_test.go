@@ -0,0 +1,57 @@
+package proto_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+func TestEventPayloadRegistry(t *testing.T) {
+	type transfer struct {
+		Amount uint64 `json:"amount"`
+	}
+
+	registry := hlfproto.NewEventPayloadRegistry()
+	for _, target := range []interface{}{nil, (*common.BlockHeader)(nil), (*transfer)(nil)} {
+		if err := registry.Register(`nil`, target); !errors.Is(err, hlfproto.ErrEventPayloadTargetNil) {
+			t.Fatalf("register %T: expected= %s, got= %v", target, hlfproto.ErrEventPayloadTargetNil, err)
+		}
+	}
+
+	if err := registry.Register(`header`, &common.BlockHeader{Number: 1}); err != nil {
+		t.Fatalf("register proto: %s", err)
+	}
+	if err := registry.Register(`transfer`, transfer{}); err != nil {
+		t.Fatalf("register json: %s", err)
+	}
+
+	header := &common.BlockHeader{Number: 10}
+	headerBytes, err := proto.Marshal(header)
+	if err != nil {
+		t.Fatalf("marshal: %s", err)
+	}
+
+	decoded, registered, err := registry.Decode(`header`, headerBytes)
+	if err != nil || !registered {
+		t.Fatalf("decode proto: registered= %t, err= %v", registered, err)
+	}
+	if !proto.Equal(decoded.(*common.BlockHeader), header) {
+		t.Fatalf("decoded proto: expected= %v, got= %v", header, decoded)
+	}
+
+	decoded, registered, err = registry.Decode(`transfer`, []byte(`{"amount":5}`))
+	if err != nil || !registered {
+		t.Fatalf("decode json: registered= %t, err= %v", registered, err)
+	}
+	if decoded.(*transfer).Amount != 5 {
+		t.Fatalf("decoded json: expected= 5, got= %d", decoded.(*transfer).Amount)
+	}
+
+	if _, registered, _ = registry.Decode(`unknown`, nil); registered {
+		t.Fatal(`unknown event is registered`)
+	}
+}
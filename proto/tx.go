@@ -60,3 +60,14 @@ func (t *Transaction) Events() []*peer.ChaincodeEvent {
 	}
 	return events
 }
+
+// ChaincodeDefinitions returns chaincode definition events from _lifecycle and lscc actions
+func (t *Transaction) ChaincodeDefinitions() []*ChaincodeDefinitionEvent {
+	var definitions []*ChaincodeDefinitionEvent
+	for _, a := range t.Actions {
+		if a.ChaincodeDefinition != nil {
+			definitions = append(definitions, a.ChaincodeDefinition)
+		}
+	}
+	return definitions
+}
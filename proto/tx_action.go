@@ -1,6 +1,7 @@
 package proto
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
@@ -13,14 +14,40 @@ import (
 
 type (
 	TransactionAction struct {
-		Event                   *peer.ChaincodeEvent          `json:"event"`
-		Endorsers               []*msp.SerializedIdentity     `json:"endorsers"`
-		ReadWriteSets           []*kvrwset.KVRWSet            `json:"rw_sets"`
+		Event     *peer.ChaincodeEvent      `json:"event"`
+		Endorsers []*msp.SerializedIdentity `json:"endorsers"`
+		// ReadWriteSets - public read/write sets of NsReadWriteSets without namespaces, kept for compatibility.
+		// Not marshalled to JSON, NsReadWriteSets contain the same sets
+		ReadWriteSets []*kvrwset.KVRWSet `json:"-"`
+		// NsReadWriteSets contains public and private data hashed read/write sets per namespace
+		NsReadWriteSets         []*NsReadWriteSet             `json:"ns_rw_sets"`
 		ChaincodeInvocationSpec *peer.ChaincodeInvocationSpec `json:"cc_invocation_spec"`
 		CreatorIdentity         msp.SerializedIdentity        `json:"creator_identity"`
+		// ChaincodeDefinition is set for _lifecycle approve / commit and lscc deploy / upgrade actions
+		ChaincodeDefinition *ChaincodeDefinitionEvent `json:"chaincode_definition,omitempty"`
+		// ChaincodeDefinitionErr is set if _lifecycle or lscc args can't be parsed, action is parsed anyway
+		ChaincodeDefinitionErr error `json:"-"`
 	}
 
 	TransactionsActions []*TransactionAction
+
+	// NsReadWriteSet - read/write set of namespace (chaincode)
+	NsReadWriteSet struct {
+		Namespace string `json:"namespace"`
+		// public reads, writes, range queries and metadata writes
+		KVRWSet *kvrwset.KVRWSet `json:"kv_rw_set"`
+		// hashed private data reads and writes per collection
+		CollectionHashedRWSets []*CollectionHashedReadWriteSet `json:"collection_hashed_rw_sets"`
+	}
+
+	// CollectionHashedReadWriteSet - hashes of private data keys and values, written to ledger instead of private data
+	CollectionHashedReadWriteSet struct {
+		CollectionName string `json:"collection_name"`
+		// hashed reads (key hash, version), writes (key hash, value hash, is delete, is purge) and metadata writes
+		HashedRWSet *kvrwset.HashedRWSet `json:"hashed_rw_set"`
+		// hash of private read/write set of collection
+		PvtRWSetHash []byte `json:"pvt_rw_set_hash"`
+	}
 )
 
 func ParseTxActions(txActions []*peer.TransactionAction) ([]*TransactionAction, error) {
@@ -63,7 +90,7 @@ func ParseTxAction(txAction *peer.TransactionAction) (*TransactionAction, error)
 		return nil, fmt.Errorf("parse transaction endorsers: %w", err)
 	}
 
-	rwSets, err := ParseTransactionActionReadWriteSet(ccAction)
+	nsRWSets, err := ParseTransactionActionNsReadWriteSet(ccAction)
 	if err != nil {
 		return nil, fmt.Errorf("parse transaction read/write sets: %w", err)
 	}
@@ -79,11 +106,15 @@ func ParseTxAction(txAction *peer.TransactionAction) (*TransactionAction, error)
 	parsedTxAction := &TransactionAction{
 		Event:                   ccEvent,
 		Endorsers:               endorsers,
-		ReadWriteSets:           rwSets,
+		ReadWriteSets:           kvReadWriteSets(nsRWSets),
+		NsReadWriteSets:         nsRWSets,
 		ChaincodeInvocationSpec: chaincodeInvocationSpec,
 		CreatorIdentity:         *creator,
 	}
 
+	// chaincode definition is optional, unexpected _lifecycle or lscc args must not fail the whole block
+	parsedTxAction.ChaincodeDefinition, parsedTxAction.ChaincodeDefinitionErr = ParseChaincodeDefinitionEvent(parsedTxAction)
+
 	return parsedTxAction, nil
 }
 
@@ -136,21 +167,85 @@ func ParseTransactionActionEndorsers(txAction *peer.TransactionAction) ([]*msp.S
 }
 
 func ParseTransactionActionReadWriteSet(chaincodeAction *peer.ChaincodeAction) ([]*kvrwset.KVRWSet, error) {
+	nsReadWriteSets, err := ParseTransactionActionNsReadWriteSet(chaincodeAction)
+	if err != nil {
+		return nil, err
+	}
+	return kvReadWriteSets(nsReadWriteSets), nil
+}
+
+// kvReadWriteSets returns public read/write sets of namespaces
+func kvReadWriteSets(nsReadWriteSets []*NsReadWriteSet) []*kvrwset.KVRWSet {
+	kvReadWriteSets := make([]*kvrwset.KVRWSet, 0, len(nsReadWriteSets))
+	for _, rw := range nsReadWriteSets {
+		kvReadWriteSets = append(kvReadWriteSets, rw.KVRWSet)
+	}
+	return kvReadWriteSets
+}
+
+func ParseTransactionActionNsReadWriteSet(chaincodeAction *peer.ChaincodeAction) ([]*NsReadWriteSet, error) {
 	txReadWriteSet := &rwset.TxReadWriteSet{}
 	if err := proto.Unmarshal(chaincodeAction.Results, txReadWriteSet); err != nil {
 		return nil, fmt.Errorf("failed to get txReadWriteSet: %w", err)
 	}
 
-	kvReadWriteSets := make([]*kvrwset.KVRWSet, 0)
+	nsReadWriteSets := make([]*NsReadWriteSet, 0)
 	for _, rw := range txReadWriteSet.NsRwset {
-		kvReadWriteSet := &kvrwset.KVRWSet{}
-		if err := proto.Unmarshal(rw.Rwset, kvReadWriteSet); err != nil {
-			return nil, fmt.Errorf("failed to get kvReadWriteSet: %w", err)
+		nsReadWriteSet := &NsReadWriteSet{
+			Namespace: rw.Namespace,
+			KVRWSet:   &kvrwset.KVRWSet{},
+		}
+		if err := proto.Unmarshal(rw.Rwset, nsReadWriteSet.KVRWSet); err != nil {
+			return nil, fmt.Errorf("failed to get kvReadWriteSet of namespace=%s: %w", rw.Namespace, err)
+		}
+
+		for _, collRW := range rw.CollectionHashedRwset {
+			collHashedRWSet := &CollectionHashedReadWriteSet{
+				CollectionName: collRW.CollectionName,
+				HashedRWSet:    &kvrwset.HashedRWSet{},
+				PvtRWSetHash:   collRW.PvtRwsetHash,
+			}
+			if err := proto.Unmarshal(collRW.HashedRwset, collHashedRWSet.HashedRWSet); err != nil {
+				return nil, fmt.Errorf("failed to get hashed read/write set of namespace=%s collection=%s: %w",
+					rw.Namespace, collRW.CollectionName, err)
+			}
+			nsReadWriteSet.CollectionHashedRWSets = append(nsReadWriteSet.CollectionHashedRWSets, collHashedRWSet)
+		}
+
+		nsReadWriteSets = append(nsReadWriteSets, nsReadWriteSet)
+	}
+
+	return nsReadWriteSets, nil
+}
+
+// NsReadWriteSet returns read/write set of namespace or nil if action does not touch namespace
+func (a *TransactionAction) NsReadWriteSet(namespace string) *NsReadWriteSet {
+	for _, rw := range a.NsReadWriteSets {
+		if rw.Namespace == namespace {
+			return rw
 		}
-		kvReadWriteSets = append(kvReadWriteSets, kvReadWriteSet)
 	}
+	return nil
+}
 
-	return kvReadWriteSets, nil
+// CollectionHashedRWSet returns hashed read/write set of private data collection or nil if not exists
+func (rw *NsReadWriteSet) CollectionHashedRWSet(collection string) *CollectionHashedReadWriteSet {
+	for _, coll := range rw.CollectionHashedRWSets {
+		if coll.CollectionName == collection {
+			return coll
+		}
+	}
+	return nil
+}
+
+// HasPrivateWrite checks that transaction wrote private data key with presented key hash
+func (c *CollectionHashedReadWriteSet) HasPrivateWrite(keyHash []byte) bool {
+	for _, w := range c.HashedRWSet.GetHashedWrites() {
+		if bytes.Equal(w.KeyHash, keyHash) {
+			return true
+		}
+	}
+	return false
 }
 
 func ParseTransactionActionChaincode(txAction *peer.TransactionAction) (*peer.ChaincodeInvocationSpec, error) {
@@ -0,0 +1,249 @@
+package proto_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric-protos-go/peer/lifecycle"
+	"github.com/hyperledger/fabric/common/policydsl"
+	"github.com/hyperledger/fabric/protoutil"
+
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+)
+
+// txAction creates endorsed transaction action invoking chaincode with args
+func txAction(creatorMSP, chaincode string, args [][]byte, results *rwset.TxReadWriteSet) *peer.TransactionAction {
+	signatureHeader := protoutil.MarshalOrPanic(&common.SignatureHeader{
+		Creator: protoutil.MarshalOrPanic(&msp.SerializedIdentity{Mspid: creatorMSP}),
+	})
+
+	chaincodeAction := &peer.ChaincodeAction{ChaincodeId: &peer.ChaincodeID{Name: chaincode}}
+	if results != nil {
+		chaincodeAction.Results = protoutil.MarshalOrPanic(results)
+	}
+
+	return &peer.TransactionAction{
+		Header: signatureHeader,
+		Payload: protoutil.MarshalOrPanic(&peer.ChaincodeActionPayload{
+			ChaincodeProposalPayload: protoutil.MarshalOrPanic(&peer.ChaincodeProposalPayload{
+				Input: protoutil.MarshalOrPanic(&peer.ChaincodeInvocationSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+					ChaincodeId: &peer.ChaincodeID{Name: chaincode},
+					Input:       &peer.ChaincodeInput{Args: args},
+				}}),
+			}),
+			Action: &peer.ChaincodeEndorsedAction{
+				ProposalResponsePayload: protoutil.MarshalOrPanic(&peer.ProposalResponsePayload{
+					Extension: protoutil.MarshalOrPanic(chaincodeAction),
+				}),
+			},
+		}),
+	}
+}
+
+func TestParseTxAction_ReadWriteSets(t *testing.T) {
+	keyHash := []byte(`key-hash`)
+	results := &rwset.TxReadWriteSet{
+		DataModel: rwset.TxReadWriteSet_KV,
+		NsRwset: []*rwset.NsReadWriteSet{{
+			Namespace: `cc`,
+			Rwset: protoutil.MarshalOrPanic(&kvrwset.KVRWSet{
+				Reads:  []*kvrwset.KVRead{{Key: `a`, Version: &kvrwset.Version{BlockNum: 1}}},
+				Writes: []*kvrwset.KVWrite{{Key: `b`, Value: []byte(`1`)}},
+			}),
+			CollectionHashedRwset: []*rwset.CollectionHashedReadWriteSet{{
+				CollectionName: `private`,
+				HashedRwset: protoutil.MarshalOrPanic(&kvrwset.HashedRWSet{
+					HashedWrites: []*kvrwset.KVWriteHash{{KeyHash: keyHash, ValueHash: []byte(`value-hash`)}},
+				}),
+				PvtRwsetHash: []byte(`pvt-hash`),
+			}},
+		}, {
+			Namespace: `_lifecycle`,
+			Rwset:     protoutil.MarshalOrPanic(&kvrwset.KVRWSet{}),
+		}},
+	}
+
+	action, err := hlfproto.ParseTxAction(txAction(`Org1MSP`, `cc`, [][]byte{[]byte(`invoke`)}, results))
+	if err != nil {
+		t.Fatalf("parse tx action: %s", err)
+	}
+
+	if len(action.ReadWriteSets) != 2 || len(action.NsReadWriteSets) != 2 {
+		t.Fatalf("expected 2 read/write sets, got %d and %d", len(action.ReadWriteSets), len(action.NsReadWriteSets))
+	}
+
+	ccRWSet := action.NsReadWriteSet(`cc`)
+	if ccRWSet == nil {
+		t.Fatal("namespace cc read/write set not found")
+	}
+	if ccRWSet.KVRWSet != action.ReadWriteSets[0] {
+		t.Fatal("public read/write set should be shared with namespace read/write set")
+	}
+
+	// public read/write sets are marshalled once, as part of namespace read/write sets
+	actionJSON, err := json.Marshal(action)
+	if err != nil {
+		t.Fatalf("marshal action: %s", err)
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(actionJSON, &fields); err != nil {
+		t.Fatalf("unmarshal action: %s", err)
+	}
+	if _, ok := fields[`rw_sets`]; ok {
+		t.Fatal("rw_sets should not be marshalled")
+	}
+	if _, ok := fields[`ns_rw_sets`]; !ok {
+		t.Fatal("ns_rw_sets not marshalled")
+	}
+	if len(ccRWSet.KVRWSet.Writes) != 1 || ccRWSet.KVRWSet.Writes[0].Key != `b` {
+		t.Fatalf("unexpected writes: %v", ccRWSet.KVRWSet.Writes)
+	}
+
+	private := ccRWSet.CollectionHashedRWSet(`private`)
+	if private == nil {
+		t.Fatal("collection hashed read/write set not found")
+	}
+	if !bytes.Equal(private.PvtRWSetHash, []byte(`pvt-hash`)) || !private.HasPrivateWrite(keyHash) {
+		t.Fatalf("unexpected collection hashed read/write set: %v", private)
+	}
+
+	if action.NsReadWriteSet(`unknown`) != nil || ccRWSet.CollectionHashedRWSet(`unknown`) != nil {
+		t.Fatal("expected nil for unknown namespace and collection")
+	}
+}
+
+func TestParseTxAction_ReadWriteSetsInvalid(t *testing.T) {
+	results := &rwset.TxReadWriteSet{NsRwset: []*rwset.NsReadWriteSet{{Namespace: `cc`, Rwset: []byte(`invalid`)}}}
+
+	if _, err := hlfproto.ParseTxAction(txAction(`Org1MSP`, `cc`, [][]byte{[]byte(`invoke`)}, results)); err == nil {
+		t.Fatal("expected error for invalid read/write set")
+	}
+}
+
+func TestParseTxAction_ChaincodeDefinition(t *testing.T) {
+	endorsementPolicy := policydsl.SignedByAnyMember([]string{`Org1MSP`, `Org2MSP`})
+	collections := &peer.CollectionConfigPackage{Config: []*peer.CollectionConfig{{
+		Payload: &peer.CollectionConfig_StaticCollectionConfig{StaticCollectionConfig: &peer.StaticCollectionConfig{
+			Name: `private`, RequiredPeerCount: 1, MaximumPeerCount: 2,
+		}},
+	}}}
+	validationParameter := protoutil.MarshalOrPanic(&peer.ApplicationPolicy{
+		Type: &peer.ApplicationPolicy_SignaturePolicy{SignaturePolicy: endorsementPolicy},
+	})
+
+	approve := txAction(`Org2MSP`, hlfproto.LifecycleNamespace, [][]byte{
+		[]byte(`ApproveChaincodeDefinitionForMyOrg`),
+		protoutil.MarshalOrPanic(&lifecycle.ApproveChaincodeDefinitionForMyOrgArgs{
+			Name:                `cc`,
+			Version:             `1.0`,
+			Sequence:            2,
+			EndorsementPlugin:   `escc`,
+			ValidationPlugin:    `vscc`,
+			ValidationParameter: validationParameter,
+			Collections:         collections,
+			InitRequired:        true,
+			Source: &lifecycle.ChaincodeSource{Type: &lifecycle.ChaincodeSource_LocalPackage{
+				LocalPackage: &lifecycle.ChaincodeSource_Local{PackageId: `cc_1.0:abc`},
+			}},
+		}),
+	}, nil)
+
+	commit := txAction(`Org1MSP`, hlfproto.LifecycleNamespace, [][]byte{
+		[]byte(`CommitChaincodeDefinition`),
+		protoutil.MarshalOrPanic(&lifecycle.CommitChaincodeDefinitionArgs{
+			Name:                `cc`,
+			Version:             `1.0`,
+			Sequence:            2,
+			ValidationParameter: validationParameter,
+		}),
+	}, nil)
+
+	deploy := txAction(`Org1MSP`, hlfproto.LSCCNamespace, [][]byte{
+		[]byte(`deploy`),
+		[]byte(`channel1`),
+		protoutil.MarshalOrPanic(&peer.ChaincodeDeploymentSpec{ChaincodeSpec: &peer.ChaincodeSpec{
+			ChaincodeId: &peer.ChaincodeID{Name: `legacy`, Version: `0.1`},
+		}}),
+		protoutil.MarshalOrPanic(endorsementPolicy),
+		[]byte(`escc`),
+		[]byte(`vscc`),
+		protoutil.MarshalOrPanic(collections),
+	}, nil)
+
+	for _, c := range []struct {
+		name     string
+		action   *peer.TransactionAction
+		expected *hlfproto.ChaincodeDefinitionEvent
+	}{
+		{`approve`, approve, &hlfproto.ChaincodeDefinitionEvent{
+			Type: hlfproto.ChaincodeDefinitionApprove, Chaincode: `cc`, Sequence: 2, Version: `1.0`,
+			PackageID: `cc_1.0:abc`, EndorsementPlugin: `escc`, ValidationPlugin: `vscc`, InitRequired: true,
+			ApprovingOrg: `Org2MSP`,
+		}},
+		{`commit`, commit, &hlfproto.ChaincodeDefinitionEvent{
+			Type: hlfproto.ChaincodeDefinitionCommit, Chaincode: `cc`, Sequence: 2, Version: `1.0`,
+			ApprovingOrg: `Org1MSP`,
+		}},
+		{`lscc deploy`, deploy, &hlfproto.ChaincodeDefinitionEvent{
+			Type: hlfproto.ChaincodeDefinitionDeploy, Chaincode: `legacy`, Version: `0.1`,
+			EndorsementPlugin: `escc`, ValidationPlugin: `vscc`, ApprovingOrg: `Org1MSP`,
+		}},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			action, err := hlfproto.ParseTxAction(c.action)
+			if err != nil {
+				t.Fatalf("parse tx action: %s", err)
+			}
+			if action.ChaincodeDefinitionErr != nil {
+				t.Fatalf("chaincode definition: %s", action.ChaincodeDefinitionErr)
+			}
+
+			definition := action.ChaincodeDefinition
+			if definition == nil {
+				t.Fatal("chaincode definition not parsed")
+			}
+
+			if !proto.Equal(definition.EndorsementPolicy.GetSignaturePolicy(), endorsementPolicy) {
+				t.Fatalf("unexpected endorsement policy: %v", definition.EndorsementPolicy)
+			}
+			if c.expected.Type != hlfproto.ChaincodeDefinitionCommit && !proto.Equal(definition.Collections, collections) {
+				t.Fatalf("unexpected collections: %v", definition.Collections)
+			}
+
+			definition.EndorsementPolicy, definition.Collections = nil, nil
+			if *definition != *c.expected {
+				t.Fatalf("expected= %+v, got= %+v", c.expected, definition)
+			}
+		})
+	}
+}
+
+func TestParseTxAction_ChaincodeDefinitionMalformed(t *testing.T) {
+	for name, action := range map[string]*peer.TransactionAction{
+		`approve`: txAction(`Org1MSP`, hlfproto.LifecycleNamespace,
+			[][]byte{[]byte(`ApproveChaincodeDefinitionForMyOrg`), []byte(`invalid`)}, nil),
+		`lscc deploy`: txAction(`Org1MSP`, hlfproto.LSCCNamespace, [][]byte{[]byte(`deploy`), []byte(`channel1`)}, nil),
+	} {
+		action, err := hlfproto.ParseTxAction(action)
+		if err != nil {
+			t.Fatalf("%s: malformed definition must not fail action parsing: %s", name, err)
+		}
+		if action.ChaincodeDefinition != nil || action.ChaincodeDefinitionErr == nil {
+			t.Fatalf("%s: expected definition error, got definition= %v", name, action.ChaincodeDefinition)
+		}
+	}
+
+	// other functions of system chaincodes are not definitions
+	action, err := hlfproto.ParseTxAction(txAction(`Org1MSP`, hlfproto.LifecycleNamespace,
+		[][]byte{[]byte(`InstallChaincode`), []byte(`invalid`)}, nil))
+	if err != nil || action.ChaincodeDefinition != nil || action.ChaincodeDefinitionErr != nil {
+		t.Fatalf("unexpected result for install: definition= %v, err= %v", action.ChaincodeDefinition, err)
+	}
+}
@@ -0,0 +1,403 @@
+// Package render converts blocks to human-readable documents with stable schema, see SchemaVersion
+package render
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric-protos-go/msp"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/hyperledger/fabric/protoutil"
+	"gopkg.in/yaml.v2"
+
+	"github.com/vitiko/hlf-sdk-go/proto"
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+type (
+	Renderer struct {
+		payloads *proto.EventPayloadRegistry
+	}
+
+	RendererOpt func(*Renderer)
+)
+
+// WithEventPayloadRegistry sets registry used for decoding event payloads
+func WithEventPayloadRegistry(registry *proto.EventPayloadRegistry) RendererOpt {
+	return func(r *Renderer) {
+		r.payloads = registry
+	}
+}
+
+func NewRenderer(opts ...RendererOpt) *Renderer {
+	r := &Renderer{}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// JSON renders block as indented JSON document
+func (r *Renderer) JSON(block *common.Block) ([]byte, error) {
+	rendered, err := r.Block(block)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(rendered, ``, `  `)
+}
+
+// YAML renders block as YAML document
+func (r *Renderer) YAML(block *common.Block) ([]byte, error) {
+	rendered, err := r.Block(block)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(rendered)
+}
+
+func (r *Renderer) Block(block *common.Block) (*Block, error) {
+	parsedBlock, err := proto.ParseBlock(block)
+	if err != nil {
+		return nil, fmt.Errorf(`parse block: %w`, err)
+	}
+
+	ordererIdentities, err := proto.ParseOrdererIdentities(block)
+	if err != nil {
+		return nil, fmt.Errorf(`parse orderer identities: %w`, err)
+	}
+
+	rendered := &Block{
+		SchemaVersion:  SchemaVersion,
+		Number:         block.Header.Number,
+		Hash:           hex.EncodeToString(protoutil.BlockHeaderHash(block.Header)),
+		PreviousHash:   hex.EncodeToString(block.Header.PreviousHash),
+		DataHash:       hex.EncodeToString(block.Header.DataHash),
+		OrdererSigners: []*Identity{},
+		Transactions:   []*Transaction{},
+	}
+
+	for _, id := range ordererIdentities {
+		rendered.OrdererSigners = append(rendered.OrdererSigners, RenderIdentity(id))
+	}
+
+	for i, envelope := range parsedBlock.Envelopes {
+		rendered.Transactions = append(rendered.Transactions, r.Transaction(i, envelope))
+	}
+
+	return rendered, nil
+}
+
+func (r *Renderer) Transaction(index int, envelope *proto.Envelope) *Transaction {
+	tx := &Transaction{
+		Index:          index,
+		TxID:           envelope.ChannelHeader.GetTxId(),
+		Type:           common.HeaderType(envelope.ChannelHeader.GetType()).String(),
+		ChannelID:      envelope.ChannelHeader.GetChannelId(),
+		ValidationCode: envelope.ValidationCode.String(),
+		Valid:          envelope.ValidationCode == peer.TxValidationCode_VALID,
+	}
+
+	if ts := envelope.ChannelHeader.GetTimestamp(); ts != nil {
+		tx.Timestamp = ts.AsTime().UTC().Format(time.RFC3339Nano)
+	}
+
+	if envelope.Transaction != nil {
+		tx.Creator = RenderIdentity(&envelope.Transaction.CreatorIdentity)
+		for _, action := range envelope.Transaction.Actions {
+			tx.Actions = append(tx.Actions, r.Action(action))
+		}
+	}
+
+	if envelope.ChannelConfig != nil {
+		tx.Config = RenderConfig(envelope.ChannelConfig)
+	}
+
+	return tx
+}
+
+func (r *Renderer) Action(action *proto.TransactionAction) *Action {
+	rendered := &Action{
+		Args:          []*Value{},
+		Endorsers:     []*Identity{},
+		ReadWriteSets: []*NsReadWriteSet{},
+	}
+
+	spec := action.ChaincodeInvocationSpec.GetChaincodeSpec()
+	rendered.Chaincode = spec.GetChaincodeId().GetName()
+	rendered.ChaincodeVersion = spec.GetChaincodeId().GetVersion()
+
+	args := spec.GetInput().GetArgs()
+	if len(args) > 0 {
+		rendered.Function = string(args[0])
+		for _, arg := range args[1:] {
+			rendered.Args = append(rendered.Args, RenderValue(arg))
+		}
+	}
+
+	for _, endorser := range action.Endorsers {
+		rendered.Endorsers = append(rendered.Endorsers, RenderIdentity(endorser))
+	}
+
+	if action.Event != nil && action.Event.EventName != `` {
+		rendered.Event = r.event(action.Event)
+	}
+
+	for _, rwSet := range action.NsReadWriteSets {
+		rendered.ReadWriteSets = append(rendered.ReadWriteSets, renderNsReadWriteSet(rwSet))
+	}
+
+	return rendered
+}
+
+func (r *Renderer) event(event *peer.ChaincodeEvent) *Event {
+	rendered := &Event{
+		Name:    event.EventName,
+		Payload: RenderValue(event.Payload),
+	}
+
+	if r.payloads != nil {
+		decoded, registered, err := r.payloads.Decode(event.EventName, event.Payload)
+		switch {
+		case !registered:
+		case err != nil:
+			rendered.DecodeError = err.Error()
+		default:
+			rendered.Decoded = decoded
+		}
+	}
+
+	return rendered
+}
+
+// RenderValue renders printable UTF-8 as string (JSON objects and arrays are decoded), other bytes as hex.
+// Returns nil for empty value
+func RenderValue(value []byte) *Value {
+	if len(value) == 0 {
+		return nil
+	}
+
+	rendered := &Value{Size: len(value)}
+
+	if !isPrintable(value) {
+		rendered.Kind = KindHex
+		rendered.Text = hex.EncodeToString(value)
+		return rendered
+	}
+
+	if trimmed := strings.TrimSpace(string(value)); strings.HasPrefix(trimmed, `{`) || strings.HasPrefix(trimmed, `[`) {
+		if decoded, err := decodeJSON(value); err == nil {
+			rendered.Kind = KindJSON
+			rendered.JSON = decoded
+			return rendered
+		}
+	}
+
+	rendered.Kind = KindString
+	rendered.Text = string(value)
+	return rendered
+}
+
+// decodeJSON decodes single JSON value, integers are kept as int64 or uint64 instead of float64
+func decodeJSON(value []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(value))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf(`unexpected data after JSON value`)
+	}
+
+	return convertNumbers(decoded), nil
+}
+
+// convertNumbers replaces integer json.Number with int64 or uint64,
+// other numbers are left as json.Number to keep precision of JSON output
+func convertNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = convertNumbers(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u
+		}
+	}
+
+	return value
+}
+
+// RenderIdentity extracts subject and issuer from PEM certificate of serialized identity
+func RenderIdentity(id *msp.SerializedIdentity) *Identity {
+	rendered := &Identity{MSPID: id.GetMspid()}
+
+	block, _ := pem.Decode(id.GetIdBytes())
+	if block == nil {
+		return rendered
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return rendered
+	}
+
+	rendered.Subject = cert.Subject.String()
+	rendered.CommonName = cert.Subject.CommonName
+	rendered.Issuer = cert.Issuer.String()
+	rendered.SerialNumber = cert.SerialNumber.String()
+
+	return rendered
+}
+
+// RenderConfig summarizes channel config, orgs and capabilities are sorted by name
+func RenderConfig(config *proto.ChannelConfig) *ConfigSummary {
+	summary := &ConfigSummary{
+		ApplicationOrgs:  []*Org{},
+		OrdererOrgs:      []*Org{},
+		Consortium:       config.Consortium,
+		Capabilities:     []string{},
+		BatchTimeout:     config.OrdererBatchTimeout,
+		HashingAlgorithm: config.HashingAlgorithm,
+		ConsensusType:    config.GetOrdererConsensusType().GetType(),
+	}
+
+	for name, app := range config.Applications {
+		summary.ApplicationOrgs = append(summary.ApplicationOrgs, &Org{
+			Name:  name,
+			MSPID: app.GetMsp().GetConfig().GetName(),
+		})
+	}
+
+	for name, orderer := range config.Orderers {
+		summary.OrdererOrgs = append(summary.OrdererOrgs, &Org{
+			Name:      name,
+			MSPID:     orderer.GetMsp().GetConfig().GetName(),
+			Endpoints: orderer.Endpoints,
+		})
+	}
+
+	sort.Slice(summary.ApplicationOrgs, func(i, j int) bool {
+		return summary.ApplicationOrgs[i].Name < summary.ApplicationOrgs[j].Name
+	})
+	sort.Slice(summary.OrdererOrgs, func(i, j int) bool {
+		return summary.OrdererOrgs[i].Name < summary.OrdererOrgs[j].Name
+	})
+
+	for capability := range config.GetCapabilities().GetCapabilities() {
+		summary.Capabilities = append(summary.Capabilities, capability)
+	}
+	sort.Strings(summary.Capabilities)
+
+	if batchSize := config.OrdererBatchSize; batchSize != nil {
+		summary.BatchSize = &BatchSize{
+			MaxMessageCount:   batchSize.MaxMessageCount,
+			AbsoluteMaxBytes:  batchSize.AbsoluteMaxBytes,
+			PreferredMaxBytes: batchSize.PreferredMaxBytes,
+		}
+	}
+
+	return summary
+}
+
+func renderNsReadWriteSet(rwSet *proto.NsReadWriteSet) *NsReadWriteSet {
+	rendered := &NsReadWriteSet{
+		Namespace: rwSet.Namespace,
+		Reads:     []*Read{},
+		Writes:    []*Write{},
+	}
+
+	for _, read := range rwSet.KVRWSet.GetReads() {
+		rendered.Reads = append(rendered.Reads, &Read{Key: renderKey(read.Key), Version: renderVersion(read.Version)})
+	}
+
+	for _, write := range rwSet.KVRWSet.GetWrites() {
+		rendered.Writes = append(rendered.Writes, &Write{
+			Key:      renderKey(write.Key),
+			IsDelete: write.IsDelete,
+			Value:    RenderValue(write.Value),
+		})
+	}
+
+	for _, coll := range rwSet.CollectionHashedRWSets {
+		renderedColl := &CollectionHashedRW{
+			Name:         coll.CollectionName,
+			HashedReads:  []string{},
+			HashedWrites: []*HashedWrite{},
+		}
+
+		for _, read := range coll.HashedRWSet.GetHashedReads() {
+			renderedColl.HashedReads = append(renderedColl.HashedReads, hex.EncodeToString(read.KeyHash))
+		}
+
+		for _, write := range coll.HashedRWSet.GetHashedWrites() {
+			renderedColl.HashedWrites = append(renderedColl.HashedWrites, &HashedWrite{
+				KeyHash:   hex.EncodeToString(write.KeyHash),
+				ValueHash: hex.EncodeToString(write.ValueHash),
+				IsDelete:  write.IsDelete,
+			})
+		}
+
+		rendered.Collections = append(rendered.Collections, renderedColl)
+	}
+
+	return rendered
+}
+
+// renderKey splits composite keys created by util.CreateCompositeKey, not printable keys are hex encoded
+func renderKey(key string) Key {
+	if strings.HasPrefix(key, "\x00") {
+		objectType, attributes := util.SplitCompositeKey(key)
+		composite := &CompositeKey{ObjectType: objectType, Attributes: attributes}
+		return Key{Key: strings.Join(append([]string{objectType}, attributes...), `:`), Composite: composite}
+	}
+
+	if !isPrintable([]byte(key)) {
+		return Key{Key: hex.EncodeToString([]byte(key)), HexEncoded: true}
+	}
+
+	return Key{Key: key}
+}
+
+func renderVersion(version *kvrwset.Version) *Version {
+	if version == nil {
+		return nil
+	}
+	return &Version{BlockNum: version.BlockNum, TxNum: version.TxNum}
+}
+
+func isPrintable(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+
+	for _, r := range string(value) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
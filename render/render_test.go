@@ -0,0 +1,128 @@
+package render_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"gopkg.in/yaml.v2"
+
+	sdktesting "github.com/vitiko/hlf-sdk-go/client/testing"
+	hlfproto "github.com/vitiko/hlf-sdk-go/proto"
+	"github.com/vitiko/hlf-sdk-go/render"
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+func TestRenderer(t *testing.T) {
+	carKey, err := util.CreateCompositeKey(`car`, []string{`A`})
+	if err != nil {
+		t.Fatalf("composite key: %s", err)
+	}
+
+	block := sdktesting.NewBlock(0, nil, sdktesting.Tx{
+		ID:             `tx1`,
+		CreatorMSP:     `Org1MSP`,
+		Chaincode:      `cars`,
+		Args:           [][]byte{[]byte(`create`), []byte(`{"id":"A"}`), {0xff, 0x01}},
+		Event:          &peer.ChaincodeEvent{EventName: `Created`, Payload: []byte(`car A`)},
+		Writes:         []sdktesting.TxWrite{{Namespace: `cars`, Key: carKey, Value: []byte(`1`)}},
+		ValidationCode: peer.TxValidationCode_VALID,
+	})
+
+	rendered, err := render.NewRenderer().Block(block)
+	if err != nil {
+		t.Fatalf("render: %s", err)
+	}
+
+	tx := rendered.Transactions[0]
+	if tx.TxID != `tx1` || tx.ValidationCode != `VALID` || tx.Type != `ENDORSER_TRANSACTION` || tx.Creator.MSPID != `Org1MSP` {
+		t.Fatalf("unexpected transaction: %+v", tx)
+	}
+
+	action := tx.Actions[0]
+	if action.Function != `create` || len(action.Args) != 2 {
+		t.Fatalf("unexpected action: %+v", action)
+	}
+	if action.Args[0].Kind != render.KindJSON || action.Args[0].JSON.(map[string]interface{})[`id`] != `A` {
+		t.Fatalf("json arg: %+v", action.Args[0])
+	}
+	if action.Args[1].Kind != render.KindHex || action.Args[1].Text != `ff01` {
+		t.Fatalf("hex arg: %+v", action.Args[1])
+	}
+	if action.Event.Name != `Created` || action.Event.Payload.Text != `car A` {
+		t.Fatalf("event: %+v", action.Event)
+	}
+
+	write := action.ReadWriteSets[0].Writes[0]
+	if write.Composite == nil || write.Composite.ObjectType != `car` || write.Key.Key != `car:A` {
+		t.Fatalf("composite key write: %+v", write)
+	}
+
+	if _, err = json.Marshal(rendered); err != nil {
+		t.Fatalf("json: %s", err)
+	}
+
+	out, err := render.NewRenderer().YAML(block)
+	if err != nil {
+		t.Fatalf("yaml: %s", err)
+	}
+	var doc map[string]interface{}
+	if err = yaml.Unmarshal(out, &doc); err != nil || doc[`schema_version`] != render.SchemaVersion {
+		t.Fatalf("yaml document: %v, err: %v", doc[`schema_version`], err)
+	}
+}
+
+func TestRenderValue_Numbers(t *testing.T) {
+	value := render.RenderValue([]byte(`{"big":12345678901234567890,"id":9007199254740993,"price":1.5}`))
+	if value.Kind != render.KindJSON {
+		t.Fatalf("kind: expected= %s, got= %s", render.KindJSON, value.Kind)
+	}
+
+	decoded := value.JSON.(map[string]interface{})
+	if decoded[`big`] != uint64(12345678901234567890) || decoded[`id`] != int64(9007199254740993) {
+		t.Fatalf("integers: unexpected %#v", decoded)
+	}
+
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		t.Fatalf("yaml: %s", err)
+	}
+	if !strings.Contains(string(out), `9007199254740993`) || !strings.Contains(string(out), `12345678901234567890`) {
+		t.Fatalf("yaml integers: %s", out)
+	}
+
+	if value = render.RenderValue([]byte(`{"id":1} trailing`)); value.Kind != render.KindString {
+		t.Fatalf("trailing data kind: expected= %s, got= %s", render.KindString, value.Kind)
+	}
+}
+
+func TestRenderer_EventDecodeError(t *testing.T) {
+	type created struct {
+		ID string `json:"id"`
+	}
+
+	registry := hlfproto.NewEventPayloadRegistry()
+	if err := registry.Register(`Created`, created{}); err != nil {
+		t.Fatalf("register: %s", err)
+	}
+
+	block := sdktesting.NewBlock(0, nil, sdktesting.Tx{
+		ID:             `tx1`,
+		CreatorMSP:     `Org1MSP`,
+		Chaincode:      `cars`,
+		Args:           [][]byte{[]byte(`create`)},
+		Event:          &peer.ChaincodeEvent{EventName: `Created`, Payload: []byte(`car A`)},
+		ValidationCode: peer.TxValidationCode_VALID,
+	})
+
+	rendered, err := render.NewRenderer(render.WithEventPayloadRegistry(registry)).Block(block)
+	if err != nil {
+		t.Fatalf("render: %s", err)
+	}
+
+	event := rendered.Transactions[0].Actions[0].Event
+	if event.Decoded != nil || event.DecodeError == `` {
+		t.Fatalf("event: expected decode error, got= %+v", event)
+	}
+}
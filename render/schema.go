@@ -0,0 +1,140 @@
+package render
+
+// SchemaVersion is increased on incompatible changes of rendered document.
+// Fields are only added within the same schema version
+const SchemaVersion = `1`
+
+// Value kinds
+const (
+	KindString = `string`
+	KindJSON   = `json`
+	KindHex    = `hex`
+)
+
+type (
+	Block struct {
+		SchemaVersion  string         `json:"schema_version" yaml:"schema_version"`
+		Number         uint64         `json:"number" yaml:"number"`
+		Hash           string         `json:"hash" yaml:"hash"`
+		PreviousHash   string         `json:"previous_hash" yaml:"previous_hash"`
+		DataHash       string         `json:"data_hash" yaml:"data_hash"`
+		OrdererSigners []*Identity    `json:"orderer_signers" yaml:"orderer_signers"`
+		Transactions   []*Transaction `json:"transactions" yaml:"transactions"`
+	}
+
+	Transaction struct {
+		Index          int            `json:"index" yaml:"index"`
+		TxID           string         `json:"tx_id" yaml:"tx_id"`
+		Type           string         `json:"type" yaml:"type"`
+		ChannelID      string         `json:"channel_id" yaml:"channel_id"`
+		Timestamp      string         `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+		ValidationCode string         `json:"validation_code" yaml:"validation_code"`
+		Valid          bool           `json:"valid" yaml:"valid"`
+		Creator        *Identity      `json:"creator,omitempty" yaml:"creator,omitempty"`
+		Actions        []*Action      `json:"actions,omitempty" yaml:"actions,omitempty"`
+		Config         *ConfigSummary `json:"config,omitempty" yaml:"config,omitempty"`
+	}
+
+	Action struct {
+		Chaincode        string            `json:"chaincode" yaml:"chaincode"`
+		ChaincodeVersion string            `json:"chaincode_version,omitempty" yaml:"chaincode_version,omitempty"`
+		Function         string            `json:"function" yaml:"function"`
+		Args             []*Value          `json:"args" yaml:"args"`
+		Endorsers        []*Identity       `json:"endorsers" yaml:"endorsers"`
+		Event            *Event            `json:"event,omitempty" yaml:"event,omitempty"`
+		ReadWriteSets    []*NsReadWriteSet `json:"rw_sets" yaml:"rw_sets"`
+	}
+
+	// Value - bytes rendered as UTF-8 string, JSON document or hex
+	Value struct {
+		Kind string      `json:"kind" yaml:"kind"`
+		Text string      `json:"text,omitempty" yaml:"text,omitempty"`
+		JSON interface{} `json:"json,omitempty" yaml:"json,omitempty"`
+		Size int         `json:"size" yaml:"size"`
+	}
+
+	Identity struct {
+		MSPID        string `json:"msp_id" yaml:"msp_id"`
+		Subject      string `json:"subject,omitempty" yaml:"subject,omitempty"`
+		CommonName   string `json:"common_name,omitempty" yaml:"common_name,omitempty"`
+		Issuer       string `json:"issuer,omitempty" yaml:"issuer,omitempty"`
+		SerialNumber string `json:"serial_number,omitempty" yaml:"serial_number,omitempty"`
+	}
+
+	Event struct {
+		Name    string      `json:"name" yaml:"name"`
+		Payload *Value      `json:"payload,omitempty" yaml:"payload,omitempty"`
+		Decoded interface{} `json:"decoded,omitempty" yaml:"decoded,omitempty"`
+		// DecodeError - error of registered payload decoder, Decoded is empty
+		DecodeError string `json:"decode_error,omitempty" yaml:"decode_error,omitempty"`
+	}
+
+	Key struct {
+		Key        string        `json:"key" yaml:"key"`
+		Composite  *CompositeKey `json:"composite,omitempty" yaml:"composite,omitempty"`
+		HexEncoded bool          `json:"hex_encoded,omitempty" yaml:"hex_encoded,omitempty"`
+	}
+
+	CompositeKey struct {
+		ObjectType string   `json:"object_type" yaml:"object_type"`
+		Attributes []string `json:"attributes" yaml:"attributes"`
+	}
+
+	NsReadWriteSet struct {
+		Namespace   string                `json:"namespace" yaml:"namespace"`
+		Reads       []*Read               `json:"reads" yaml:"reads"`
+		Writes      []*Write              `json:"writes" yaml:"writes"`
+		Collections []*CollectionHashedRW `json:"collections,omitempty" yaml:"collections,omitempty"`
+	}
+
+	Read struct {
+		Key     `yaml:",inline"`
+		Version *Version `json:"version,omitempty" yaml:"version,omitempty"`
+	}
+
+	Write struct {
+		Key      `yaml:",inline"`
+		IsDelete bool   `json:"is_delete,omitempty" yaml:"is_delete,omitempty"`
+		Value    *Value `json:"value,omitempty" yaml:"value,omitempty"`
+	}
+
+	Version struct {
+		BlockNum uint64 `json:"block_num" yaml:"block_num"`
+		TxNum    uint64 `json:"tx_num" yaml:"tx_num"`
+	}
+
+	CollectionHashedRW struct {
+		Name         string         `json:"name" yaml:"name"`
+		HashedReads  []string       `json:"hashed_reads" yaml:"hashed_reads"`
+		HashedWrites []*HashedWrite `json:"hashed_writes" yaml:"hashed_writes"`
+	}
+
+	HashedWrite struct {
+		KeyHash   string `json:"key_hash" yaml:"key_hash"`
+		ValueHash string `json:"value_hash,omitempty" yaml:"value_hash,omitempty"`
+		IsDelete  bool   `json:"is_delete,omitempty" yaml:"is_delete,omitempty"`
+	}
+
+	ConfigSummary struct {
+		ApplicationOrgs  []*Org     `json:"application_orgs" yaml:"application_orgs"`
+		OrdererOrgs      []*Org     `json:"orderer_orgs" yaml:"orderer_orgs"`
+		Consortium       string     `json:"consortium,omitempty" yaml:"consortium,omitempty"`
+		Capabilities     []string   `json:"capabilities" yaml:"capabilities"`
+		ConsensusType    string     `json:"consensus_type,omitempty" yaml:"consensus_type,omitempty"`
+		BatchTimeout     string     `json:"batch_timeout,omitempty" yaml:"batch_timeout,omitempty"`
+		BatchSize        *BatchSize `json:"batch_size,omitempty" yaml:"batch_size,omitempty"`
+		HashingAlgorithm string     `json:"hashing_algorithm,omitempty" yaml:"hashing_algorithm,omitempty"`
+	}
+
+	Org struct {
+		Name      string   `json:"name" yaml:"name"`
+		MSPID     string   `json:"msp_id" yaml:"msp_id"`
+		Endpoints []string `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	}
+
+	BatchSize struct {
+		MaxMessageCount   uint32 `json:"max_message_count" yaml:"max_message_count"`
+		AbsoluteMaxBytes  uint32 `json:"absolute_max_bytes" yaml:"absolute_max_bytes"`
+		PreferredMaxBytes uint32 `json:"preferred_max_bytes" yaml:"preferred_max_bytes"`
+	}
+)
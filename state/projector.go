@@ -0,0 +1,117 @@
+// Package state reconstructs chaincode world state from block read/write sets
+package state
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+
+	"github.com/vitiko/hlf-sdk-go/util"
+	"github.com/vitiko/hlf-sdk-go/util/blockwalk"
+)
+
+const (
+	// simple (non composite) keys start from this value, as in chaincode shim range queries
+	emptyKeySubstitute = "\x01"
+	maxUnicodeRune     = string(utf8.MaxRune)
+)
+
+var (
+	ErrBlockNumberGap = blockwalk.ErrBlockNumberGap
+)
+
+type (
+	// Projector applies public writes of valid transactions to Store
+	Projector struct {
+		store  Store
+		walker *blockwalk.Walker
+	}
+
+	ProjectorOpt func(*Projector)
+)
+
+// WithNamespaces limits projected namespaces (chaincodes), all namespaces are projected by default
+func WithNamespaces(namespaces ...string) ProjectorOpt {
+	return func(p *Projector) {
+		p.walker.AddNamespaces(namespaces...)
+	}
+}
+
+func NewProjector(store Store, opts ...ProjectorOpt) *Projector {
+	p := &Projector{
+		store: store,
+	}
+	p.walker = blockwalk.New(store.Height, p.commit)
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Run applies blocks from stream until stream is closed or context is done
+func (p *Projector) Run(ctx context.Context, blocks <-chan *common.Block) error {
+	return p.walker.Run(ctx, blocks)
+}
+
+// Apply applies block writes to store. Blocks below store height are skipped,
+// block above height returns ErrBlockNumberGap
+func (p *Projector) Apply(block *common.Block) error {
+	return p.walker.Apply(block)
+}
+
+// Height returns number of next block to apply
+func (p *Projector) Height() (uint64, error) {
+	return p.store.Height()
+}
+
+// Get returns value of key, nil if key does not exist
+func (p *Projector) Get(namespace, key string) (*VersionedValue, error) {
+	return p.store.Get(namespace, key)
+}
+
+// GetRange returns simple (non composite) keys from startKey (inclusive) to endKey (exclusive),
+// as chaincode GetStateByRange
+func (p *Projector) GetRange(namespace, startKey, endKey string) ([]*VersionedKV, error) {
+	if startKey == `` {
+		startKey = emptyKeySubstitute
+	}
+	if endKey == `` {
+		endKey = maxUnicodeRune
+	}
+
+	return p.store.Range(namespace, startKey, endKey)
+}
+
+// GetByPartialCompositeKey returns keys created with util.CreateCompositeKey
+// with object type and leading attributes, as chaincode GetStateByPartialCompositeKey
+func (p *Projector) GetByPartialCompositeKey(namespace, objectType string, attributes []string) ([]*VersionedKV, error) {
+	prefix, err := util.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, fmt.Errorf(`create composite key: %w`, err)
+	}
+
+	return p.store.Range(namespace, prefix, prefix+maxUnicodeRune)
+}
+
+func (p *Projector) commit(blockNum uint64, writes []*blockwalk.Write) error {
+	updates := make([]*Update, 0, len(writes))
+	for _, write := range writes {
+		updates = append(updates, &Update{
+			Namespace: write.Namespace,
+			Key:       write.Key,
+			Value:     write.Value,
+			IsDelete:  write.IsDelete,
+			Version:   Version{BlockNum: write.BlockNum, TxNum: write.TxNum},
+		})
+	}
+
+	if err := p.store.Commit(blockNum, updates); err != nil {
+		return fmt.Errorf(`commit block=%d: %w`, blockNum, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package state_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	sdktesting "github.com/vitiko/hlf-sdk-go/client/testing"
+	"github.com/vitiko/hlf-sdk-go/state"
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+func compositeKey(t *testing.T, objectType string, attrs ...string) string {
+	key, err := util.CreateCompositeKey(objectType, attrs)
+	if err != nil {
+		t.Fatalf("create composite key: %s", err)
+	}
+	return key
+}
+
+func TestProjector(t *testing.T) {
+	carA, carB, owner := compositeKey(t, `car`, `A`), compositeKey(t, `car`, `B`), compositeKey(t, `owner`, `A`)
+
+	b0 := sdktesting.NewBlock(0, nil,
+		sdktesting.Tx{ValidationCode: peer.TxValidationCode_VALID, Writes: []sdktesting.TxWrite{
+			{Namespace: `cc`, Key: `a`, Value: []byte(`1`)},
+			{Namespace: `cc`, Key: `b`, Value: []byte(`2`)},
+			{Namespace: `cc`, Key: carA, Value: []byte(`car A`)},
+			{Namespace: `cc`, Key: owner, Value: []byte(`owner A`)},
+			{Namespace: `other`, Key: `a`, Value: []byte(`other`)},
+		}})
+	b1 := sdktesting.NewBlock(1, b0.Header,
+		sdktesting.Tx{ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, Writes: []sdktesting.TxWrite{
+			{Namespace: `cc`, Key: `a`, Value: []byte(`invalid`)},
+		}},
+		sdktesting.Tx{ValidationCode: peer.TxValidationCode_VALID, Writes: []sdktesting.TxWrite{
+			{Namespace: `cc`, Key: `b`, IsDelete: true},
+			{Namespace: `cc`, Key: `c`, Value: []byte(`3`)},
+			{Namespace: `cc`, Key: carB, Value: []byte(`car B`)},
+		}})
+
+	blocks := make(chan *common.Block, 3)
+	blocks <- b0
+	blocks <- b0 // already applied block is skipped
+	blocks <- b1
+	close(blocks)
+
+	projector := state.NewProjector(state.NewMemoryStore(), state.WithNamespaces(`cc`))
+	if err := projector.Run(context.Background(), blocks); err != nil {
+		t.Fatalf("run: %s", err)
+	}
+
+	if height, _ := projector.Height(); height != 2 {
+		t.Fatalf("height: expected= 2, got= %d", height)
+	}
+
+	a, err := projector.Get(`cc`, `a`)
+	if err != nil || a == nil || string(a.Value) != `1` || a.Version != (state.Version{BlockNum: 0, TxNum: 0}) {
+		t.Fatalf("unexpected value of a: %v, err: %v", a, err)
+	}
+
+	if b, _ := projector.Get(`cc`, `b`); b != nil {
+		t.Fatalf("deleted key b exists: %v", b)
+	}
+
+	if other, _ := projector.Get(`other`, `a`); other != nil {
+		t.Fatalf("not projected namespace is in state: %v", other)
+	}
+
+	kvs, err := projector.GetRange(`cc`, ``, ``)
+	if err != nil {
+		t.Fatalf("range: %s", err)
+	}
+	if keys := kvKeys(kvs); len(keys) != 2 || keys[0] != `a` || keys[1] != `c` {
+		t.Fatalf("range keys: %q", keys)
+	}
+
+	kvs, err = projector.GetByPartialCompositeKey(`cc`, `car`, nil)
+	if err != nil {
+		t.Fatalf("composite range: %s", err)
+	}
+	if keys := kvKeys(kvs); len(keys) != 2 || keys[0] != carA || keys[1] != carB {
+		t.Fatalf("composite keys: %q", keys)
+	}
+	if kvs[1].Version != (state.Version{BlockNum: 1, TxNum: 1}) {
+		t.Fatalf("version of car B: %v", kvs[1].Version)
+	}
+
+	if err = projector.Apply(sdktesting.NewBlock(5, b1.Header)); !errors.Is(err, state.ErrBlockNumberGap) {
+		t.Fatalf("gap: expected= %s, got= %v", state.ErrBlockNumberGap, err)
+	}
+}
+
+func kvKeys(kvs []*state.VersionedKV) []string {
+	var keys []string
+	for _, kv := range kvs {
+		keys = append(keys, kv.Key)
+	}
+	return keys
+}
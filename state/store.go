@@ -0,0 +1,149 @@
+package state
+
+import (
+	"sort"
+	"sync"
+)
+
+type (
+	// Version of key - height of transaction wrote key
+	Version struct {
+		BlockNum uint64 `json:"block_num"`
+		TxNum    uint64 `json:"tx_num"`
+	}
+
+	VersionedValue struct {
+		Value   []byte  `json:"value"`
+		Version Version `json:"version"`
+	}
+
+	VersionedKV struct {
+		Namespace string `json:"namespace"`
+		Key       string `json:"key"`
+		VersionedValue
+	}
+
+	// Update - write or delete of key by valid transaction
+	Update struct {
+		Namespace string
+		Key       string
+		Value     []byte
+		IsDelete  bool
+		Version   Version
+	}
+
+	// Store - versioned key-value storage of world state
+	Store interface {
+		// Get returns nil if key does not exist
+		Get(namespace, key string) (*VersionedValue, error)
+		// Range returns keys from startKey (inclusive) to endKey (exclusive) sorted by key,
+		// empty endKey means range to the last key of namespace
+		Range(namespace, startKey, endKey string) ([]*VersionedKV, error)
+		// Height returns number of next block to apply
+		Height() (uint64, error)
+		// Commit applies updates of block atomically and sets height to blockNum + 1
+		Commit(blockNum uint64, updates []*Update) error
+	}
+
+	// MemoryStore - in-memory Store, keys of namespace are kept sorted for range scans
+	MemoryStore struct {
+		mu         sync.RWMutex
+		height     uint64
+		namespaces map[string]*memoryNamespace
+	}
+
+	memoryNamespace struct {
+		values map[string]*VersionedValue
+		keys   []string
+	}
+)
+
+var _ Store = (*MemoryStore)(nil)
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		namespaces: make(map[string]*memoryNamespace),
+	}
+}
+
+func (m *MemoryStore) Get(namespace, key string) (*VersionedValue, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns, ok := m.namespaces[namespace]
+	if !ok {
+		return nil, nil
+	}
+
+	return ns.values[key], nil
+}
+
+func (m *MemoryStore) Range(namespace, startKey, endKey string) ([]*VersionedKV, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ns, ok := m.namespaces[namespace]
+	if !ok {
+		return nil, nil
+	}
+
+	var kvs []*VersionedKV
+	for i := sort.SearchStrings(ns.keys, startKey); i < len(ns.keys); i++ {
+		key := ns.keys[i]
+		if endKey != `` && key >= endKey {
+			break
+		}
+		kvs = append(kvs, &VersionedKV{Namespace: namespace, Key: key, VersionedValue: *ns.values[key]})
+	}
+
+	return kvs, nil
+}
+
+func (m *MemoryStore) Height() (uint64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.height, nil
+}
+
+func (m *MemoryStore) Commit(blockNum uint64, updates []*Update) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, u := range updates {
+		ns, ok := m.namespaces[u.Namespace]
+		if !ok {
+			ns = &memoryNamespace{values: make(map[string]*VersionedValue)}
+			m.namespaces[u.Namespace] = ns
+		}
+
+		if u.IsDelete {
+			ns.delete(u.Key)
+			continue
+		}
+		ns.put(u.Key, &VersionedValue{Value: u.Value, Version: u.Version})
+	}
+
+	m.height = blockNum + 1
+	return nil
+}
+
+func (n *memoryNamespace) put(key string, value *VersionedValue) {
+	if _, exists := n.values[key]; !exists {
+		i := sort.SearchStrings(n.keys, key)
+		n.keys = append(n.keys, ``)
+		copy(n.keys[i+1:], n.keys[i:])
+		n.keys[i] = key
+	}
+	n.values[key] = value
+}
+
+func (n *memoryNamespace) delete(key string) {
+	if _, exists := n.values[key]; !exists {
+		return
+	}
+	delete(n.values, key)
+
+	i := sort.SearchStrings(n.keys, key)
+	n.keys = append(n.keys[:i], n.keys[i+1:]...)
+}
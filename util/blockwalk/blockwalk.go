@@ -0,0 +1,137 @@
+// Package blockwalk applies blocks from stream in order and extracts public writes of valid transactions,
+// it is shared by world state projector and key history indexer
+package blockwalk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hyperledger/fabric-protos-go/common"
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	"github.com/vitiko/hlf-sdk-go/proto"
+)
+
+var (
+	ErrBlockNumberGap = proto.ErrBlockNumberGap
+)
+
+type (
+	// Write - write or delete of key by valid transaction
+	Write struct {
+		Namespace string
+		Key       string
+		Value     []byte
+		IsDelete  bool
+		BlockNum  uint64
+		TxNum     uint64
+		// Envelope of transaction, i.e. for tx id, timestamp and creator
+		Envelope *proto.Envelope
+	}
+
+	// HeightFunc returns number of next block to apply
+	HeightFunc func() (uint64, error)
+
+	// CommitFunc applies writes of block atomically, next block to apply is blockNum + 1
+	CommitFunc func(blockNum uint64, writes []*Write) error
+
+	// Walker checks block numbers against height and commits writes of blocks
+	Walker struct {
+		height     HeightFunc
+		commit     CommitFunc
+		namespaces map[string]struct{}
+	}
+)
+
+func New(height HeightFunc, commit CommitFunc) *Walker {
+	return &Walker{
+		height: height,
+		commit: commit,
+	}
+}
+
+// AddNamespaces limits namespaces (chaincodes) of writes, writes of all namespaces are committed by default
+func (w *Walker) AddNamespaces(namespaces ...string) {
+	if w.namespaces == nil {
+		w.namespaces = make(map[string]struct{})
+	}
+	for _, ns := range namespaces {
+		w.namespaces[ns] = struct{}{}
+	}
+}
+
+// Run applies blocks from stream until stream is closed or context is done
+func (w *Walker) Run(ctx context.Context, blocks <-chan *common.Block) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case block, ok := <-blocks:
+			if !ok {
+				return nil
+			}
+			if err := w.Apply(block); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Apply commits block writes. Blocks below height are skipped,
+// block above height returns ErrBlockNumberGap
+func (w *Walker) Apply(block *common.Block) error {
+	height, err := w.height()
+	if err != nil {
+		return fmt.Errorf(`height: %w`, err)
+	}
+
+	num := block.GetHeader().GetNumber()
+	switch {
+	case num < height:
+		return nil
+	case num > height:
+		return fmt.Errorf(`expected block=%d, got=%d: %w`, height, num, ErrBlockNumberGap)
+	}
+
+	parsedBlock, err := proto.ParseBlock(block)
+	if err != nil {
+		return fmt.Errorf(`parse block=%d: %w`, num, err)
+	}
+
+	var writes []*Write
+	for txNum, envelope := range parsedBlock.Envelopes {
+		if envelope.ValidationCode != peer.TxValidationCode_VALID || envelope.Transaction == nil {
+			continue
+		}
+
+		for _, action := range envelope.Transaction.Actions {
+			for _, rwSet := range action.NsReadWriteSets {
+				if !w.matched(rwSet.Namespace) {
+					continue
+				}
+
+				for _, write := range rwSet.KVRWSet.GetWrites() {
+					writes = append(writes, &Write{
+						Namespace: rwSet.Namespace,
+						Key:       write.Key,
+						Value:     write.Value,
+						IsDelete:  write.IsDelete,
+						BlockNum:  num,
+						TxNum:     uint64(txNum),
+						Envelope:  envelope,
+					})
+				}
+			}
+		}
+	}
+
+	return w.commit(num, writes)
+}
+
+func (w *Walker) matched(namespace string) bool {
+	if len(w.namespaces) == 0 {
+		return true
+	}
+	_, ok := w.namespaces[namespace]
+	return ok
+}
@@ -0,0 +1,65 @@
+package blockwalk_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hyperledger/fabric-protos-go/peer"
+
+	sdktesting "github.com/vitiko/hlf-sdk-go/client/testing"
+	"github.com/vitiko/hlf-sdk-go/util/blockwalk"
+)
+
+func TestWalker_Apply(t *testing.T) {
+	b0 := sdktesting.NewBlock(0, nil,
+		sdktesting.Tx{ID: `tx1`, ValidationCode: peer.TxValidationCode_VALID, Writes: []sdktesting.TxWrite{
+			{Namespace: `cc`, Key: `a`, Value: []byte(`1`)},
+			{Namespace: `other`, Key: `a`, Value: []byte(`other`)},
+		}})
+	b1 := sdktesting.NewBlock(1, b0.Header,
+		sdktesting.Tx{ID: `tx2`, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, Writes: []sdktesting.TxWrite{
+			{Namespace: `cc`, Key: `a`, Value: []byte(`invalid`)},
+		}},
+		sdktesting.Tx{ID: `tx3`, ValidationCode: peer.TxValidationCode_VALID, Writes: []sdktesting.TxWrite{
+			{Namespace: `cc`, Key: `a`, IsDelete: true},
+		}})
+	b2 := sdktesting.NewBlock(2, b1.Header)
+
+	var (
+		height  uint64
+		commits [][]*blockwalk.Write
+	)
+	walker := blockwalk.New(
+		func() (uint64, error) { return height, nil },
+		func(blockNum uint64, writes []*blockwalk.Write) error {
+			commits = append(commits, writes)
+			height = blockNum + 1
+			return nil
+		})
+	walker.AddNamespaces(`cc`)
+
+	if err := walker.Apply(b0); err != nil {
+		t.Fatalf("apply: %s", err)
+	}
+	// already applied block is skipped
+	if err := walker.Apply(b0); err != nil {
+		t.Fatalf("apply again: %s", err)
+	}
+	if err := walker.Apply(b2); !errors.Is(err, blockwalk.ErrBlockNumberGap) {
+		t.Fatalf("apply with gap: expected= %s, got= %v", blockwalk.ErrBlockNumberGap, err)
+	}
+	if err := walker.Apply(b1); err != nil {
+		t.Fatalf("apply: %s", err)
+	}
+
+	if len(commits) != 2 || len(commits[0]) != 1 || len(commits[1]) != 1 {
+		t.Fatalf("commits: expected= 2 with 1 write, got= %v", commits)
+	}
+
+	if w := commits[0][0]; w.Namespace != `cc` || string(w.Value) != `1` || w.Envelope.ChannelHeader.TxId != `tx1` {
+		t.Fatalf("write: unexpected %+v", w)
+	}
+	if w := commits[1][0]; !w.IsDelete || w.BlockNum != 1 || w.TxNum != 1 || w.Envelope.ChannelHeader.TxId != `tx3` {
+		t.Fatalf("delete: unexpected %+v", w)
+	}
+}
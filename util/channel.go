@@ -35,7 +35,8 @@ func GetOrdererHostFromChannelConfig(conf *common.Config) (string, error) {
 	return ordererAddresses.Addresses[0], nil
 }
 
-// ProceedChannelUpdate - sends channel update config with signatures of all provided identities
+// ProceedChannelUpdate - sends channel update config with signatures of all provided identities.
+// If org admins sign update on different hosts, use chanconfig.SignUpdate and chanconfig.MergeSignatures
 func ProceedChannelUpdate(
 	ctx context.Context,
 	channelName string,
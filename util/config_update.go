@@ -0,0 +1,249 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+)
+
+var (
+	ErrConfigChannelGroupMissing = errors.New(`channel group is missing in config`)
+	ErrConfigNoDifferences       = errors.New(`no differences detected between original and updated config`)
+)
+
+// ComputeConfigUpdate returns minimal config update transforming original config to updated one,
+// the same as `configtxlator compute_update`.
+// Read set contains versions of elements used for update, write set contains modified elements with
+// incremented versions. Group version is incremented only if group members or group mod policy changed
+func ComputeConfigUpdate(channelID string, original, updated *common.Config) (*common.ConfigUpdate, error) {
+	if original.GetChannelGroup() == nil {
+		return nil, fmt.Errorf(`original: %w`, ErrConfigChannelGroupMissing)
+	}
+
+	if updated.GetChannelGroup() == nil {
+		return nil, fmt.Errorf(`updated: %w`, ErrConfigChannelGroupMissing)
+	}
+
+	readSet, writeSet, groupUpdated := computeGroupUpdate(original.ChannelGroup, updated.ChannelGroup)
+	if !groupUpdated {
+		return nil, ErrConfigNoDifferences
+	}
+
+	return &common.ConfigUpdate{
+		ChannelId: channelID,
+		ReadSet:   readSet,
+		WriteSet:  writeSet,
+	}, nil
+}
+
+// computeGroupUpdate returns read and write sets of group and whether group differs
+func computeGroupUpdate(original, updated *common.ConfigGroup) (readSet, writeSet *common.ConfigGroup, groupUpdated bool) {
+	policies := computePoliciesUpdate(original.Policies, updated.Policies)
+	values := computeValuesUpdate(original.Values, updated.Values)
+	groups := computeGroupsUpdate(original.Groups, updated.Groups)
+
+	membersUpdated := policies.membersUpdated || values.membersUpdated || groups.membersUpdated ||
+		original.ModPolicy != updated.ModPolicy
+
+	if !membersUpdated {
+		// group itself is the same, but some elements may be modified
+		if len(policies.writeSet) == 0 && len(values.writeSet) == 0 && len(groups.writeSet) == 0 {
+			return &common.ConfigGroup{Version: original.Version}, &common.ConfigGroup{Version: original.Version}, false
+		}
+
+		return &common.ConfigGroup{
+			Version:  original.Version,
+			Policies: policies.readSet,
+			Values:   values.readSet,
+			Groups:   groups.readSet,
+		}, &common.ConfigGroup{
+			Version:  original.Version,
+			Policies: policies.writeSet,
+			Values:   values.writeSet,
+			Groups:   groups.writeSet,
+		}, true
+	}
+
+	// members of group changed: group version is incremented and all retained elements are in read and write sets
+	for name, same := range policies.sameSet {
+		policies.readSet[name] = same
+		policies.writeSet[name] = same
+	}
+
+	for name, same := range values.sameSet {
+		values.readSet[name] = same
+		values.writeSet[name] = same
+	}
+
+	for name, same := range groups.sameSet {
+		groups.readSet[name] = same
+		groups.writeSet[name] = same
+	}
+
+	return &common.ConfigGroup{
+		Version:  original.Version,
+		Policies: policies.readSet,
+		Values:   values.readSet,
+		Groups:   groups.readSet,
+	}, &common.ConfigGroup{
+		Version:   original.Version + 1,
+		ModPolicy: updated.ModPolicy,
+		Policies:  policies.writeSet,
+		Values:    values.writeSet,
+		Groups:    groups.writeSet,
+	}, true
+}
+
+type (
+	policiesUpdate struct {
+		readSet, writeSet, sameSet map[string]*common.ConfigPolicy
+		membersUpdated             bool
+	}
+
+	valuesUpdate struct {
+		readSet, writeSet, sameSet map[string]*common.ConfigValue
+		membersUpdated             bool
+	}
+
+	groupsUpdate struct {
+		readSet, writeSet, sameSet map[string]*common.ConfigGroup
+		membersUpdated             bool
+	}
+)
+
+func computePoliciesUpdate(original, updated map[string]*common.ConfigPolicy) policiesUpdate {
+	u := policiesUpdate{
+		readSet:  make(map[string]*common.ConfigPolicy),
+		writeSet: make(map[string]*common.ConfigPolicy),
+		sameSet:  make(map[string]*common.ConfigPolicy),
+	}
+
+	for name, originalPolicy := range original {
+		updatedPolicy, ok := updated[name]
+		if !ok {
+			u.membersUpdated = true
+			continue
+		}
+
+		if originalPolicy.ModPolicy == updatedPolicy.ModPolicy && proto.Equal(originalPolicy.Policy, updatedPolicy.Policy) {
+			u.sameSet[name] = &common.ConfigPolicy{Version: originalPolicy.Version}
+			continue
+		}
+
+		u.writeSet[name] = &common.ConfigPolicy{
+			Version:   originalPolicy.Version + 1,
+			ModPolicy: updatedPolicy.ModPolicy,
+			Policy:    updatedPolicy.Policy,
+		}
+	}
+
+	for name, updatedPolicy := range updated {
+		if _, ok := original[name]; ok {
+			continue
+		}
+		u.membersUpdated = true
+		u.writeSet[name] = &common.ConfigPolicy{
+			Version:   0,
+			ModPolicy: updatedPolicy.ModPolicy,
+			Policy:    updatedPolicy.Policy,
+		}
+	}
+
+	return u
+}
+
+func computeValuesUpdate(original, updated map[string]*common.ConfigValue) valuesUpdate {
+	u := valuesUpdate{
+		readSet:  make(map[string]*common.ConfigValue),
+		writeSet: make(map[string]*common.ConfigValue),
+		sameSet:  make(map[string]*common.ConfigValue),
+	}
+
+	for name, originalValue := range original {
+		updatedValue, ok := updated[name]
+		if !ok {
+			u.membersUpdated = true
+			continue
+		}
+
+		if originalValue.ModPolicy == updatedValue.ModPolicy && bytes.Equal(originalValue.Value, updatedValue.Value) {
+			u.sameSet[name] = &common.ConfigValue{Version: originalValue.Version}
+			continue
+		}
+
+		u.writeSet[name] = &common.ConfigValue{
+			Version:   originalValue.Version + 1,
+			ModPolicy: updatedValue.ModPolicy,
+			Value:     updatedValue.Value,
+		}
+	}
+
+	for name, updatedValue := range updated {
+		if _, ok := original[name]; ok {
+			continue
+		}
+		u.membersUpdated = true
+		u.writeSet[name] = &common.ConfigValue{
+			Version:   0,
+			ModPolicy: updatedValue.ModPolicy,
+			Value:     updatedValue.Value,
+		}
+	}
+
+	return u
+}
+
+func computeGroupsUpdate(original, updated map[string]*common.ConfigGroup) groupsUpdate {
+	u := groupsUpdate{
+		readSet:  make(map[string]*common.ConfigGroup),
+		writeSet: make(map[string]*common.ConfigGroup),
+		sameSet:  make(map[string]*common.ConfigGroup),
+	}
+
+	for name, originalGroup := range original {
+		updatedGroup, ok := updated[name]
+		if !ok {
+			u.membersUpdated = true
+			continue
+		}
+
+		groupReadSet, groupWriteSet, groupUpdated := computeGroupUpdate(originalGroup, updatedGroup)
+		if !groupUpdated {
+			u.sameSet[name] = groupReadSet
+			continue
+		}
+
+		u.readSet[name] = groupReadSet
+		u.writeSet[name] = groupWriteSet
+	}
+
+	for name, updatedGroup := range updated {
+		if _, ok := original[name]; ok {
+			continue
+		}
+		u.membersUpdated = true
+
+		// new group is written entirely with zero versions
+		_, groupWriteSet, _ := computeGroupUpdate(newConfigGroup(), updatedGroup)
+		u.writeSet[name] = &common.ConfigGroup{
+			Version:   0,
+			ModPolicy: updatedGroup.ModPolicy,
+			Policies:  groupWriteSet.Policies,
+			Values:    groupWriteSet.Values,
+			Groups:    groupWriteSet.Groups,
+		}
+	}
+
+	return u
+}
+
+func newConfigGroup() *common.ConfigGroup {
+	return &common.ConfigGroup{
+		Groups:   make(map[string]*common.ConfigGroup),
+		Values:   make(map[string]*common.ConfigValue),
+		Policies: make(map[string]*common.ConfigPolicy),
+	}
+}
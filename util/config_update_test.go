@@ -0,0 +1,192 @@
+package util_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric-protos-go/common"
+
+	"github.com/vitiko/hlf-sdk-go/util"
+)
+
+func configWithGroup(group *common.ConfigGroup) *common.Config {
+	return &common.Config{ChannelGroup: group}
+}
+
+// expected updates are the same as produced by `configtxlator compute_update`
+func TestComputeConfigUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		original *common.ConfigGroup
+		updated  *common.ConfigGroup
+		expected *common.ConfigUpdate
+		err      error
+	}{
+		{
+			name:     `no differences`,
+			original: &common.ConfigGroup{Version: 7, ModPolicy: `Admins`},
+			updated:  &common.ConfigGroup{Version: 7, ModPolicy: `Admins`},
+			err:      util.ErrConfigNoDifferences,
+		},
+		{
+			name:     `missing channel group`,
+			original: nil,
+			updated:  &common.ConfigGroup{},
+			err:      util.ErrConfigChannelGroupMissing,
+		},
+		{
+			name: `group mod policy update`,
+			original: &common.ConfigGroup{Version: 5, ModPolicy: `Admins`,
+				Values: map[string]*common.ConfigValue{`Foo`: {Version: 3, ModPolicy: `Admins`, Value: []byte(`foo`)}}},
+			updated: &common.ConfigGroup{Version: 5, ModPolicy: `Writers`,
+				Values: map[string]*common.ConfigValue{`Foo`: {Version: 3, ModPolicy: `Admins`, Value: []byte(`foo`)}}},
+			expected: &common.ConfigUpdate{
+				ChannelId: `channel`,
+				ReadSet: &common.ConfigGroup{Version: 5,
+					Values: map[string]*common.ConfigValue{`Foo`: {Version: 3}}},
+				WriteSet: &common.ConfigGroup{Version: 6, ModPolicy: `Writers`,
+					Values: map[string]*common.ConfigValue{`Foo`: {Version: 3}}},
+			},
+		},
+		{
+			name: `value modification`,
+			original: &common.ConfigGroup{Version: 1,
+				Values: map[string]*common.ConfigValue{
+					`Foo`: {Version: 3, ModPolicy: `Admins`, Value: []byte(`foo`)},
+					`Bar`: {Version: 2, ModPolicy: `Admins`, Value: []byte(`bar`)},
+				}},
+			updated: &common.ConfigGroup{Version: 1,
+				Values: map[string]*common.ConfigValue{
+					`Foo`: {Version: 3, ModPolicy: `Admins`, Value: []byte(`foo updated`)},
+					`Bar`: {Version: 2, ModPolicy: `Admins`, Value: []byte(`bar`)},
+				}},
+			expected: &common.ConfigUpdate{
+				ChannelId: `channel`,
+				ReadSet:   &common.ConfigGroup{Version: 1},
+				WriteSet: &common.ConfigGroup{Version: 1,
+					Values: map[string]*common.ConfigValue{`Foo`: {Version: 4, ModPolicy: `Admins`, Value: []byte(`foo updated`)}}},
+			},
+		},
+		{
+			name: `value addition`,
+			original: &common.ConfigGroup{Version: 1,
+				Values: map[string]*common.ConfigValue{`Foo`: {Version: 3, ModPolicy: `Admins`, Value: []byte(`foo`)}}},
+			updated: &common.ConfigGroup{Version: 1,
+				Values: map[string]*common.ConfigValue{
+					`Foo`: {Version: 3, ModPolicy: `Admins`, Value: []byte(`foo`)},
+					`Bar`: {Version: 0, ModPolicy: `Admins`, Value: []byte(`bar`)},
+				}},
+			expected: &common.ConfigUpdate{
+				ChannelId: `channel`,
+				ReadSet: &common.ConfigGroup{Version: 1,
+					Values: map[string]*common.ConfigValue{`Foo`: {Version: 3}}},
+				WriteSet: &common.ConfigGroup{Version: 2,
+					Values: map[string]*common.ConfigValue{
+						`Foo`: {Version: 3},
+						`Bar`: {Version: 0, ModPolicy: `Admins`, Value: []byte(`bar`)},
+					}},
+			},
+		},
+		{
+			name: `policy removal`,
+			original: &common.ConfigGroup{Version: 4,
+				Policies: map[string]*common.ConfigPolicy{
+					`Readers`: {Version: 1, ModPolicy: `Admins`, Policy: &common.Policy{Type: 3}},
+					`Writers`: {Version: 2, ModPolicy: `Admins`, Policy: &common.Policy{Type: 3}},
+				}},
+			updated: &common.ConfigGroup{Version: 4,
+				Policies: map[string]*common.ConfigPolicy{
+					`Readers`: {Version: 1, ModPolicy: `Admins`, Policy: &common.Policy{Type: 3}},
+				}},
+			expected: &common.ConfigUpdate{
+				ChannelId: `channel`,
+				ReadSet: &common.ConfigGroup{Version: 4,
+					Policies: map[string]*common.ConfigPolicy{`Readers`: {Version: 1}}},
+				WriteSet: &common.ConfigGroup{Version: 5,
+					Policies: map[string]*common.ConfigPolicy{`Readers`: {Version: 1}}},
+			},
+		},
+		{
+			name: `nested group modification`,
+			original: &common.ConfigGroup{Version: 0,
+				Groups: map[string]*common.ConfigGroup{
+					`Application`: {Version: 1, ModPolicy: `Admins`,
+						Groups: map[string]*common.ConfigGroup{
+							`Org1`: {Version: 2, ModPolicy: `Admins`,
+								Values: map[string]*common.ConfigValue{`AnchorPeers`: {Version: 0, ModPolicy: `Admins`, Value: []byte(`a`)}}},
+							`Org2`: {Version: 3, ModPolicy: `Admins`},
+						}},
+					`Orderer`: {Version: 4, ModPolicy: `Admins`},
+				}},
+			updated: &common.ConfigGroup{Version: 0,
+				Groups: map[string]*common.ConfigGroup{
+					`Application`: {Version: 1, ModPolicy: `Admins`,
+						Groups: map[string]*common.ConfigGroup{
+							`Org1`: {Version: 2, ModPolicy: `Admins`,
+								Values: map[string]*common.ConfigValue{`AnchorPeers`: {Version: 0, ModPolicy: `Admins`, Value: []byte(`b`)}}},
+							`Org2`: {Version: 3, ModPolicy: `Admins`},
+						}},
+					`Orderer`: {Version: 4, ModPolicy: `Admins`},
+				}},
+			expected: &common.ConfigUpdate{
+				ChannelId: `channel`,
+				ReadSet: &common.ConfigGroup{Version: 0,
+					Groups: map[string]*common.ConfigGroup{
+						`Application`: {Version: 1,
+							Groups: map[string]*common.ConfigGroup{`Org1`: {Version: 2}}},
+					}},
+				WriteSet: &common.ConfigGroup{Version: 0,
+					Groups: map[string]*common.ConfigGroup{
+						`Application`: {Version: 1,
+							Groups: map[string]*common.ConfigGroup{
+								`Org1`: {Version: 2,
+									Values: map[string]*common.ConfigValue{`AnchorPeers`: {Version: 1, ModPolicy: `Admins`, Value: []byte(`b`)}}},
+							}},
+					}},
+			},
+		},
+		{
+			name: `group addition`,
+			original: &common.ConfigGroup{Version: 1,
+				Groups: map[string]*common.ConfigGroup{`Org1`: {Version: 2, ModPolicy: `Admins`}}},
+			updated: &common.ConfigGroup{Version: 1,
+				Groups: map[string]*common.ConfigGroup{
+					`Org1`: {Version: 2, ModPolicy: `Admins`},
+					`Org2`: {Version: 0, ModPolicy: `Admins`,
+						Values: map[string]*common.ConfigValue{`MSP`: {ModPolicy: `Admins`, Value: []byte(`msp`)}}},
+				}},
+			expected: &common.ConfigUpdate{
+				ChannelId: `channel`,
+				ReadSet: &common.ConfigGroup{Version: 1,
+					Groups: map[string]*common.ConfigGroup{`Org1`: {Version: 2}}},
+				WriteSet: &common.ConfigGroup{Version: 2,
+					Groups: map[string]*common.ConfigGroup{
+						`Org1`: {Version: 2},
+						`Org2`: {Version: 0, ModPolicy: `Admins`,
+							Values: map[string]*common.ConfigValue{`MSP`: {Version: 0, ModPolicy: `Admins`, Value: []byte(`msp`)}}},
+					}},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			update, err := util.ComputeConfigUpdate(`channel`, configWithGroup(tc.original), configWithGroup(tc.updated))
+			if tc.err != nil {
+				if !errors.Is(err, tc.err) {
+					t.Fatalf("error: expected= %s, got= %v", tc.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !proto.Equal(update, tc.expected) {
+				t.Fatalf("update:\nexpected= %v\ngot=      %v", tc.expected, update)
+			}
+		})
+	}
+}